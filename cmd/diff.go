@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+	"github.com/spf13/cobra"
+)
+
+var errBreakingChanges = errors.New("breaking changes found")
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <old.yml> <new.yml>",
+	Short: "Report API changes between two schema versions",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputJSON, err := cmd.Flags().GetBool("input-json")
+		if err != nil {
+			return err
+		}
+		format := schema.InputAuto
+		if inputJSON {
+			format = schema.InputJSON
+		}
+
+		outFormat, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+
+		changes, err := schema.DiffFiles(args[0], args[1], format)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "error: %v\n", err)
+			return err
+		}
+
+		out, err := schema.FormatChanges(changes, outFormat)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "error: %v\n", err)
+			return err
+		}
+		fmt.Fprint(cmd.OutOrStdout(), out)
+
+		if n := schema.BreakingChanges(changes); n > 0 {
+			return fmt.Errorf("%w: %d", errBreakingChanges, n)
+		}
+		return nil
+	},
+}
+
+func init() {
+	diffCmd.Flags().String("format", schema.DiffText, "Output format: text, json or markdown")
+	diffCmd.Flags().Bool("input-json", false, "Treat schema inputs as JSON (default: detected from extension or content)")
+	rootCmd.AddCommand(diffCmd)
+}
@@ -2,8 +2,12 @@ package cmd
 
 import (
 	"errors"
+	"maps"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/brownhounds/openapi-tsgen/config"
 	"github.com/brownhounds/openapi-tsgen/schema"
 	"github.com/brownhounds/openapi-tsgen/ver"
 	"github.com/spf13/cobra"
@@ -27,8 +31,7 @@ var rootCmd = &cobra.Command{
 			in = args[0]
 		}
 		if in == "" {
-			_ = cmd.Help()
-			return nil
+			return runConfig(cmd)
 		}
 
 		out, err := cmd.Flags().GetString("output")
@@ -44,22 +47,181 @@ var rootCmd = &cobra.Command{
 			return err
 		}
 
-		format := schema.InputYAML
-		if inputJSON {
-			format = schema.InputJSON
+		client, err := cmd.Flags().GetString("client")
+		if err != nil {
+			return err
 		}
 
-		if err := schema.WriteSchema(in, out, format); err != nil {
+		zod, err := cmd.Flags().GetString("zod")
+		if err != nil {
 			return err
 		}
-		return nil
+
+		strict, err := cmd.Flags().GetBool("strict")
+		if err != nil {
+			return err
+		}
+
+		operationAliases, err := cmd.Flags().GetBool("operation-aliases")
+		if err != nil {
+			return err
+		}
+
+		componentAliases, err := cmd.Flags().GetBool("component-aliases")
+		if err != nil {
+			return err
+		}
+
+		aliasPrefix, err := cmd.Flags().GetString("component-alias-prefix")
+		if err != nil {
+			return err
+		}
+
+		aliasSuffix, err := cmd.Flags().GetString("component-alias-suffix")
+		if err != nil {
+			return err
+		}
+
+		enumStyle, err := cmd.Flags().GetString("enum-style")
+		if err != nil {
+			return err
+		}
+
+		split, err := cmd.Flags().GetString("split")
+		if err != nil {
+			return err
+		}
+
+		formats, err := cmd.Flags().GetStringToString("format-type")
+		if err != nil {
+			return err
+		}
+
+		brands, err := cmd.Flags().GetBool("brands")
+		if err != nil {
+			return err
+		}
+
+		preserveOrder, err := cmd.Flags().GetBool("preserve-order")
+		if err != nil {
+			return err
+		}
+
+		fixedTuples, err := cmd.Flags().GetBool("fixed-tuples")
+		if err != nil {
+			return err
+		}
+
+		target := config.Target{
+			Input:                in,
+			Output:               out,
+			Client:               client,
+			Zod:                  zod,
+			InputJSON:            inputJSON,
+			Strict:               strict,
+			OperationAliases:     operationAliases,
+			ComponentAliases:     componentAliases,
+			ComponentAliasPrefix: aliasPrefix,
+			ComponentAliasSuffix: aliasSuffix,
+			EnumStyle:            enumStyle,
+			Split:                split,
+			Formats:              formats,
+			Brands:               brands,
+			PreserveOrder:        preserveOrder,
+			FixedTuples:          fixedTuples,
+		}
+
+		watch, err := cmd.Flags().GetBool("watch")
+		if err != nil {
+			return err
+		}
+		if watch {
+			return watchUntilInterrupt(cmd, []config.Target{target})
+		}
+		std, err := readStdin(cmd, []config.Target{target})
+		if err != nil {
+			return err
+		}
+		diags, err := runTarget(target, std)
+		reportDiagnostics(cmd.ErrOrStderr(), diags)
+		return err
 	},
 }
 
+func runConfig(cmd *cobra.Command) error {
+	path, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		path, err = config.Discover(wd)
+		if err != nil {
+			return err
+		}
+	}
+	if path == "" {
+		_ = cmd.Help()
+		return nil
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+
+	strict, err := cmd.Flags().GetBool("strict")
+	if err != nil {
+		return err
+	}
+	if strict {
+		for i := range cfg.Targets {
+			cfg.Targets[i].Strict = true
+		}
+	}
+
+	watch, err := cmd.Flags().GetBool("watch")
+	if err != nil {
+		return err
+	}
+	if watch {
+		return watchUntilInterrupt(cmd, cfg.Targets)
+	}
+	std, err := readStdin(cmd, cfg.Targets)
+	if err != nil {
+		return err
+	}
+	return runTargets(cmd.ErrOrStderr(), cfg.Targets, std)
+}
+
+func watchUntilInterrupt(cmd *cobra.Command, targets []config.Target) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return watchTargets(ctx, cmd.ErrOrStderr(), stdio{out: cmd.OutOrStdout()}, targets)
+}
+
 func init() {
-	rootCmd.Flags().StringP("schema", "s", "", "Path to OpenAPI schema (YAML)")
-	rootCmd.Flags().StringP("output", "o", "type.ts", "Output file path")
-	rootCmd.Flags().Bool("input-json", false, "Treat schema input as JSON")
+	rootCmd.Flags().StringP("schema", "s", "", "Path to OpenAPI schema (YAML or JSON, - for stdin)")
+	rootCmd.Flags().StringP("output", "o", "type.ts", "Output file path (- for stdout)")
+	rootCmd.Flags().Bool("input-json", false, "Treat schema input as JSON (default: detected from extension or content)")
+	rootCmd.Flags().String("client", "", "Also generate a typed fetch client at this path")
+	rootCmd.Flags().String("zod", "", "Also generate zod validators at this path")
+	rootCmd.Flags().Bool("strict", false, "Fail when the schema uses constructs that fall back to unknown")
+	rootCmd.Flags().Bool("component-aliases", false, "Also export a top-level alias per component schema, response, request body, parameter and header")
+	rootCmd.Flags().String("component-alias-prefix", "", "Prefix for component aliases")
+	rootCmd.Flags().String("component-alias-suffix", "", "Suffix for component aliases")
+	rootCmd.Flags().StringToString("format-type", maps.Clone(schema.DefaultFormats), "TypeScript type for a schema format, e.g. date-time=Date,int64=bigint")
+	rootCmd.Flags().Bool("preserve-order", false, "Keep properties, routes, methods, parameters and responses in source order instead of sorting them")
+	rootCmd.Flags().Bool("fixed-tuples", false, "Emit arrays whose minItems equals maxItems (up to 8) as fixed-length tuples")
+	rootCmd.Flags().Bool("brands", false, "Emit branded types with type guards for constrained primitive schemas and x-ts-brand")
+	rootCmd.Flags().String("enum-style", string(schema.EnumConst), "Enum output: const-enum, enum, union or object")
+	rootCmd.Flags().String("split", "", "Write a directory of modules instead of one file: section or tag")
+	rootCmd.Flags().Bool("operation-aliases", false, "Also export Params, Query, Body and Response aliases per operation")
+	rootCmd.Flags().Bool("watch", false, "Regenerate whenever the schema or a file it references changes")
+	rootCmd.Flags().String("config", "", "Path to an openapi-tsgen.yaml config (default: discovered in the working directory)")
 }
 
 func Execute() {
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/brownhounds/openapi-tsgen/config"
+	"github.com/brownhounds/openapi-tsgen/schema"
+	"github.com/spf13/cobra"
+)
+
+var errTargetsFailed = errors.New("targets failed")
+
+func targetFormat(t config.Target) schema.InputFormat {
+	if t.InputJSON {
+		return schema.InputJSON
+	}
+	return schema.InputAuto
+}
+
+func targetOptions(t config.Target) schema.Options {
+	return schema.Options{
+		Format:               targetFormat(t),
+		Formats:              t.Formats,
+		ComponentAliasPrefix: t.ComponentAliasPrefix,
+		ComponentAliasSuffix: t.ComponentAliasSuffix,
+		EnumStyle:            schema.EnumStyle(t.EnumStyle),
+		Split:                schema.SplitMode(t.Split),
+		ComponentAliases:     t.ComponentAliases,
+		OperationAliases:     t.OperationAliases,
+		Brands:               t.Brands,
+		PreserveOrder:        t.PreserveOrder,
+		FixedTuples:          t.FixedTuples,
+	}
+}
+
+type stdio struct {
+	out io.Writer
+	in  []byte
+}
+
+func readStdin(cmd *cobra.Command, targets []config.Target) (stdio, error) {
+	std := stdio{out: cmd.OutOrStdout()}
+	for _, t := range targets {
+		if t.Input != schema.StdioPath {
+			continue
+		}
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return std, fmt.Errorf("read schema from stdin: %w", err)
+		}
+		std.in = data
+		break
+	}
+	return std, nil
+}
+
+func runTarget(t config.Target, std stdio) ([]schema.Diagnostic, error) {
+	paths := schema.OutputPaths{
+		Stdout: std.out,
+		Types:  t.Output,
+		Client: t.Client,
+		Zod:    t.Zod,
+	}
+	var diags []schema.Diagnostic
+	var err error
+	if t.Input == schema.StdioPath {
+		diags, err = schema.WriteOutputsFrom(std.in, paths, targetOptions(t))
+	} else {
+		diags, err = schema.WriteOutputs(t.Input, paths, targetOptions(t))
+	}
+	if err != nil {
+		return diags, err
+	}
+	if t.Strict && len(diags) > 0 {
+		return diags, fmt.Errorf("%w: %d", schema.ErrStrictDiagnostics, len(diags))
+	}
+	return diags, nil
+}
+
+func runTargets(w io.Writer, targets []config.Target, std stdio) error {
+	diags := make([][]schema.Diagnostic, len(targets))
+	errs := make([]error, len(targets))
+	sem := make(chan struct{}, runtime.NumCPU())
+
+	var wg sync.WaitGroup
+	for i := range targets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			diags[i], errs[i] = runTarget(targets[i], std)
+		}(i)
+	}
+	wg.Wait()
+
+	failed := 0
+	for i, t := range targets {
+		if errs[i] != nil {
+			failed++
+		}
+		reportTarget(w, t, diags[i], errs[i])
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", errTargetsFailed, failed, len(targets))
+	}
+	return nil
+}
+
+func reportTarget(w io.Writer, t config.Target, diags []schema.Diagnostic, err error) {
+	name := t.Name
+	if name == "" {
+		name = t.Input
+	}
+	for _, d := range diags {
+		fmt.Fprintf(w, "warn %s: %s\n", name, d)
+	}
+	if err != nil {
+		fmt.Fprintf(w, "FAIL %s: %v\n", name, err)
+		return
+	}
+	fmt.Fprintf(w, "ok   %s -> %s\n", name, t.Output)
+}
+
+func reportDiagnostics(w io.Writer, diags []schema.Diagnostic) {
+	for _, d := range diags {
+		fmt.Fprintf(w, "warning: %s\n", d)
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+	"github.com/spf13/cobra"
+)
+
+var errValidationFailed = errors.New("validation failed")
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [schema.yml]",
+	Short: "Check an OpenAPI schema for structural errors",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		in, err := cmd.Flags().GetString("schema")
+		if err != nil {
+			return err
+		}
+		if in == "" && len(args) > 0 {
+			in = args[0]
+		}
+		if in == "" {
+			_ = cmd.Help()
+			return nil
+		}
+
+		inputJSON, err := cmd.Flags().GetBool("input-json")
+		if err != nil {
+			return err
+		}
+		format := schema.InputAuto
+		if inputJSON {
+			format = schema.InputJSON
+		}
+
+		var errs []schema.ValidationError
+		if in == schema.StdioPath {
+			var data []byte
+			data, err = io.ReadAll(cmd.InOrStdin())
+			if err != nil {
+				return fmt.Errorf("read schema from stdin: %w", err)
+			}
+			errs, err = schema.ValidateData(data, format)
+		} else {
+			errs, err = schema.Validate(in, format)
+		}
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "error: %v\n", err)
+			return err
+		}
+
+		w := cmd.OutOrStdout()
+		wd, _ := os.Getwd()
+		for _, e := range errs {
+			if rel, err := filepath.Rel(wd, e.File); err == nil && wd != "" {
+				e.File = rel
+			}
+			if rel, err := filepath.Rel(wd, e.RelatedFile); err == nil && wd != "" && e.RelatedFile != "" {
+				e.RelatedFile = rel
+			}
+			fmt.Fprintln(w, e)
+		}
+		if len(errs) > 0 {
+			fmt.Fprintf(w, "%d problem(s) found\n", len(errs))
+			return fmt.Errorf("%w: %d problem(s)", errValidationFailed, len(errs))
+		}
+		fmt.Fprintf(w, "%s: no problems found\n", in)
+		return nil
+	},
+}
+
+func init() {
+	validateCmd.Flags().StringP("schema", "s", "", "Path to OpenAPI schema (YAML or JSON, - for stdin)")
+	validateCmd.Flags().Bool("input-json", false, "Treat schema input as JSON (default: detected from extension or content)")
+	rootCmd.AddCommand(validateCmd)
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/brownhounds/openapi-tsgen/config"
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+const (
+	watchInterval = 250 * time.Millisecond
+	watchDebounce = 300 * time.Millisecond
+)
+
+var errWatchStdin = errors.New("cannot watch a schema read from stdin")
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+type watchedTarget struct {
+	files  map[string]fileStamp
+	target config.Target
+	dirty  bool
+}
+
+func watchTargets(ctx context.Context, w io.Writer, std stdio, targets []config.Target) error {
+	for _, t := range targets {
+		if t.Input == schema.StdioPath {
+			return errWatchStdin
+		}
+	}
+
+	watched := make([]*watchedTarget, 0, len(targets))
+	for _, t := range targets {
+		wt := &watchedTarget{target: t}
+		diags, err := runTarget(t, std)
+		reportTarget(w, t, diags, err)
+		wt.refresh()
+		watched = append(watched, wt)
+	}
+	fmt.Fprintln(w, "watching for changes, press Ctrl+C to stop")
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	var lastChange time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			for _, wt := range watched {
+				if wt.changed() {
+					wt.dirty = true
+					lastChange = now
+				}
+			}
+			if lastChange.IsZero() || now.Sub(lastChange) < watchDebounce {
+				continue
+			}
+			lastChange = time.Time{}
+			for _, wt := range watched {
+				if !wt.dirty {
+					continue
+				}
+				wt.dirty = false
+				diags, err := runTarget(wt.target, std)
+				reportTarget(w, wt.target, diags, err)
+				wt.refresh()
+			}
+		}
+	}
+}
+
+func (wt *watchedTarget) refresh() {
+	format := targetFormat(wt.target)
+	files := map[string]fileStamp{}
+	if abs, err := filepath.Abs(wt.target.Input); err == nil {
+		files[abs] = statFile(abs)
+	}
+	paths, _ := schema.SchemaFiles(wt.target.Input, format)
+	for _, p := range paths {
+		files[p] = statFile(p)
+	}
+	for p := range wt.files {
+		if _, ok := files[p]; !ok {
+			files[p] = statFile(p)
+		}
+	}
+	wt.files = files
+}
+
+func (wt *watchedTarget) changed() bool {
+	changed := false
+	for p, prev := range wt.files {
+		cur := statFile(p)
+		if cur != prev {
+			wt.files[p] = cur
+			changed = true
+		}
+	}
+	return changed
+}
+
+func statFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size(), exists: true}
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"go.yaml.in/yaml/v3"
+)
+
+var FileNames = []string{"openapi-tsgen.yaml", "openapi-tsgen.yml"}
+
+const stdioPath = "-"
+
+var (
+	ErrNoTargets     = errors.New("config has no targets")
+	ErrTargetInput   = errors.New("target input is required")
+	ErrTargetOutput  = errors.New("target output is required")
+	ErrDuplicateName = errors.New("duplicate target name")
+	ErrSharedStdout  = errors.New("only one target can write to stdout")
+)
+
+type Config struct {
+	Targets []Target `yaml:"targets"`
+}
+
+type Target struct {
+	Formats              map[string]string `yaml:"formats"`
+	Name                 string            `yaml:"name"`
+	Input                string            `yaml:"input"`
+	Output               string            `yaml:"output"`
+	Client               string            `yaml:"client"`
+	Zod                  string            `yaml:"zod"`
+	ComponentAliasPrefix string            `yaml:"componentAliasPrefix"`
+	ComponentAliasSuffix string            `yaml:"componentAliasSuffix"`
+	EnumStyle            string            `yaml:"enumStyle"`
+	Split                string            `yaml:"split"`
+	InputJSON            bool              `yaml:"inputJson"`
+	Strict               bool              `yaml:"strict"`
+	ComponentAliases     bool              `yaml:"componentAliases"`
+	OperationAliases     bool              `yaml:"operationAliases"`
+	Brands               bool              `yaml:"brands"`
+	PreserveOrder        bool              `yaml:"preserveOrder"`
+	FixedTuples          bool              `yaml:"fixedTuples"`
+}
+
+func Discover(dir string) (string, error) {
+	for _, name := range FileNames {
+		path := filepath.Join(dir, name)
+		info, err := os.Stat(path)
+		if err == nil && !info.IsDir() {
+			return path, nil
+		}
+		if err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("stat config %q: %w", path, err)
+		}
+	}
+	return "", nil
+}
+
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config %q: %w", path, err)
+	}
+
+	var cfg Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parse config %q: %w", path, err)
+	}
+	if len(cfg.Targets) == 0 {
+		return nil, fmt.Errorf("%q: %w", path, ErrNoTargets)
+	}
+
+	base := filepath.Dir(path)
+	seen := map[string]bool{}
+	stdout := ""
+	for i := range cfg.Targets {
+		t := &cfg.Targets[i]
+		if t.Input == "" {
+			return nil, fmt.Errorf("%q: targets[%d]: %w", path, i, ErrTargetInput)
+		}
+		if t.Output == "" {
+			return nil, fmt.Errorf("%q: targets[%d]: %w", path, i, ErrTargetOutput)
+		}
+		if t.Name == "" {
+			t.Name = t.Input
+		}
+		if seen[t.Name] {
+			return nil, fmt.Errorf("%q: %w: %s", path, ErrDuplicateName, t.Name)
+		}
+		seen[t.Name] = true
+		if t.Output == stdioPath || t.Client == stdioPath || t.Zod == stdioPath {
+			if stdout != "" {
+				return nil, fmt.Errorf("%q: %w: %s, %s", path, ErrSharedStdout, stdout, t.Name)
+			}
+			stdout = t.Name
+		}
+		t.Input = resolvePath(base, t.Input)
+		t.Output = resolvePath(base, t.Output)
+		t.Client = resolvePath(base, t.Client)
+		t.Zod = resolvePath(base, t.Zod)
+	}
+	return &cfg, nil
+}
+
+func resolvePath(base, p string) string {
+	if p == "" || p == stdioPath || filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(base, p)
+}
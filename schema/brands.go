@@ -0,0 +1,177 @@
+package schema
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var brandConstraintKeys = []string{
+	"format",
+	"pattern",
+	"minLength",
+	"maxLength",
+	"minimum",
+	"maximum",
+	"exclusiveMinimum",
+	"exclusiveMaximum",
+	"multipleOf",
+}
+
+var brandFormatChecks = map[string]string{
+	"uuid":      `/^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$/i.test(value)`,
+	"email":     `/^[^\s@]+@[^\s@]+\.[^\s@]+$/.test(value)`,
+	"date":      `/^\d{4}-\d{2}-\d{2}$/.test(value)`,
+	"date-time": `!Number.isNaN(Date.parse(value))`,
+	"uri":       `/^[a-zA-Z][a-zA-Z\d+.-]*:/.test(value)`,
+	"ipv4":      `/^(\d{1,3}\.){3}\d{1,3}$/.test(value)`,
+}
+
+type IRBrand struct {
+	Constraints map[string]any
+	Name        string
+	Base        string
+	Integer     bool
+}
+
+func brandType(name string) *TypeNode {
+	return &TypeNode{Kind: TypeBrand, Name: name}
+}
+
+func (c *enumContext) brandType(o map[string]any, component string) (*TypeNode, bool) {
+	if c == nil || c.brands == nil || o == nil {
+		return nil, false
+	}
+	for _, k := range []string{"enum", "const", "allOf", "oneOf", "anyOf"} {
+		if _, ok := o[k]; ok {
+			return nil, false
+		}
+	}
+	t, _ := o["type"].(string)
+	base := schemaTypeString
+	switch t {
+	case schemaTypeString:
+	case "number", "integer":
+		base = "number"
+	default:
+		return nil, false
+	}
+
+	name, _ := o["x-ts-brand"].(string)
+	if name == "" {
+		if component == "" || !brandConstrained(o) {
+			return nil, false
+		}
+		name = component
+	}
+	if !isIdent(name) {
+		name = pascalIdent(name)
+	}
+
+	if _, exists := c.brands[name]; !exists {
+		label := name
+		for i := 2; c.used[name]; i++ {
+			name = label + strconv.Itoa(i)
+		}
+		c.used[name] = true
+		constraints := map[string]any{}
+		for _, k := range brandConstraintKeys {
+			if v, ok := o[k]; ok {
+				constraints[k] = v
+			}
+		}
+		c.brands[name] = IRBrand{Name: name, Base: base, Integer: t == "integer", Constraints: constraints}
+	}
+	return applyNullable(brandType(name), o), true
+}
+
+func brandConstrained(o map[string]any) bool {
+	for _, k := range brandConstraintKeys {
+		if _, ok := o[k]; !ok {
+			continue
+		}
+		if k != "format" {
+			return true
+		}
+		format, _ := o[k].(string)
+		if _, ok := brandFormatChecks[format]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func renderTSBrand(br IRBrand) string {
+	var b strings.Builder
+	b.WriteString("export type " + br.Name + " = " + br.Base + " & { readonly __brand: " + strconv.Quote(br.Name) + " };\n\n")
+	b.WriteString("export function is" + br.Name + "(value: unknown): value is " + br.Name + " {\n")
+	b.WriteString("  return " + strings.Join(brandChecks(br), " && ") + ";\n")
+	b.WriteString("}\n\n")
+	return b.String()
+}
+
+func brandChecks(br IRBrand) []string {
+	checks := []string{"typeof value === " + strconv.Quote(br.Base)}
+	if br.Integer {
+		checks = append(checks, "Number.isInteger(value)")
+	}
+	c := br.Constraints
+	if format, ok := c["format"].(string); ok && br.Base == schemaTypeString {
+		if check, ok := brandFormatChecks[format]; ok {
+			checks = append(checks, check)
+		}
+	}
+	if p, ok := c["pattern"].(string); ok && p != "" {
+		checks = append(checks, "new RegExp("+strconv.Quote(p)+").test(value)")
+	}
+	bounds := []struct {
+		key    string
+		op     string
+		lhs    string
+		string bool
+	}{
+		{"minLength", ">=", "value.length", true},
+		{"maxLength", "<=", "value.length", true},
+		{"minimum", ">=", "value", false},
+		{"maximum", "<=", "value", false},
+		{"exclusiveMinimum", ">", "value", false},
+		{"exclusiveMaximum", "<", "value", false},
+	}
+	for _, bound := range bounds {
+		if bound.string != (br.Base == schemaTypeString) {
+			continue
+		}
+		if v, ok := brandBound(c, bound.key); ok {
+			checks = append(checks, bound.lhs+" "+bound.op+" "+v)
+		}
+	}
+	if v, ok := zodNumberLiteral(c["multipleOf"]); ok && br.Base == "number" {
+		checks = append(checks, "Number.isInteger(value / "+v+")")
+	}
+	return checks
+}
+
+func brandBound(c map[string]any, key string) (string, bool) {
+	exclusive := map[string]string{"minimum": "exclusiveMinimum", "maximum": "exclusiveMaximum"}
+	if excl, ok := c[exclusive[key]].(bool); ok && excl {
+		return "", false
+	}
+	if v, ok := zodNumberLiteral(c[key]); ok {
+		return v, true
+	}
+	if excl, ok := c[key].(bool); ok && excl {
+		return zodNumberLiteral(c[strings.ToLower(strings.TrimPrefix(key, "exclusive"))])
+	}
+	return "", false
+}
+
+func sortedBrands(brands map[string]IRBrand) []IRBrand {
+	out := make([]IRBrand, 0, len(brands))
+	for _, br := range brands {
+		out = append(out, br)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
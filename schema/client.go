@@ -0,0 +1,342 @@
+package schema
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const clientTypes = `type HttpMethod = "delete" | "get" | "head" | "options" | "patch" | "post" | "put" | "trace";
+
+type ParamStyle = "simple" | "label" | "matrix" | "form" | "spaceDelimited" | "pipeDelimited" | "deepObject";
+
+type ParamSerialization = {
+  style: ParamStyle;
+  explode: boolean;
+};
+
+type OperationSerialization = {
+  path?: Record<string, ParamSerialization>;
+  query?: Record<string, ParamSerialization>;
+};
+
+type RoutePath<M extends HttpMethod> = {
+  [P in keyof Routes]: M extends keyof Routes[P] ? P : never;
+}[keyof Routes];
+
+type RouteOperation<P, M extends HttpMethod> = P extends keyof Routes
+  ? M extends keyof Routes[P]
+    ? Routes[P][M]
+    : never
+  : never;
+
+type OptionalIfEmpty<K extends string, T> = {} extends T ? { [Q in K]?: T } : { [Q in K]: T };
+
+export type RequestOptions<O> = (O extends { params: infer T } ? { params: T } : { params?: never }) &
+  (O extends { query: infer T } ? OptionalIfEmpty<"query", T> : { query?: never }) &
+  (O extends { headers: infer T }
+    ? OptionalIfEmpty<"headers", T & Record<string, unknown>>
+    : { headers?: Record<string, unknown> }) &
+  (O extends { requestBody: infer T } ? { body: T } : { body?: never }) & {
+    init?: Omit<RequestInit, "method" | "body" | "headers">;
+  };
+
+type RequestArgs<O> = {} extends RequestOptions<O> ? [options?: RequestOptions<O>] : [options: RequestOptions<O>];
+
+type ResponseData<R> = R extends { headers: unknown; body: infer B } ? B : R;
+
+type ResponseStatus<S> = S extends "default" ? number : S;
+
+export type ApiResponse<O> = O extends { responses: infer R }
+  ? {
+      [S in keyof R]: {
+        status: ResponseStatus<S>;
+        data: ResponseData<R[S]>;
+        response: Response;
+      };
+    }[keyof R]
+  : never;
+
+export type ClientOptions = {
+  baseUrl?: string;
+  fetch?: typeof fetch;
+  headers?: Record<string, string>;
+};
+
+type RawRequestOptions = {
+  params?: Record<string, unknown>;
+  query?: Record<string, unknown>;
+  headers?: Record<string, unknown>;
+  body?: unknown;
+  init?: RequestInit;
+};
+`
+
+const clientRuntime = `function buildPath(path: string, params: Record<string, unknown>, styles: Record<string, ParamSerialization>): string {
+  return path.replace(/\{([^}]+)\}/g, (_match: string, name: string) => {
+    const serialization = styles[name] ?? { style: "simple", explode: false };
+    return serializePathParam(name, params[name], serialization);
+  });
+}
+
+function serializePathParam(name: string, value: unknown, { style, explode }: ParamSerialization): string {
+  const prefix = style === "label" ? "." : style === "matrix" ? ";" : "";
+  if (Array.isArray(value)) {
+    const items = value.map((item) => encodeURIComponent(String(item)));
+    if (style === "matrix") {
+      return explode ? items.map((item) => ";" + name + "=" + item).join("") : ";" + name + "=" + items.join(",");
+    }
+    return prefix + items.join(explode && style === "label" ? "." : ",");
+  }
+  if (value !== null && typeof value === "object") {
+    const entries = Object.entries(value).map(([k, v]) => [encodeURIComponent(k), encodeURIComponent(String(v))]);
+    if (explode) {
+      const separator = style === "label" ? "." : style === "matrix" ? ";" : ",";
+      return prefix + entries.map(([k, v]) => k + "=" + v).join(separator);
+    }
+    const flat = entries.flat().join(",");
+    return style === "matrix" ? ";" + name + "=" + flat : prefix + flat;
+  }
+  const encoded = encodeURIComponent(String(value));
+  return style === "matrix" ? ";" + name + "=" + encoded : prefix + encoded;
+}
+
+function buildQuery(query: Record<string, unknown>, styles: Record<string, ParamSerialization>): string {
+  const parts: string[] = [];
+  for (const [name, value] of Object.entries(query)) {
+    if (value === undefined || value === null) {
+      continue;
+    }
+    const { style, explode } = styles[name] ?? { style: "form", explode: true };
+    const key = encodeURIComponent(name);
+    if (Array.isArray(value)) {
+      const items = value.map((item) => encodeURIComponent(String(item)));
+      if (explode) {
+        for (const item of items) {
+          parts.push(key + "=" + item);
+        }
+        continue;
+      }
+      const separator = style === "spaceDelimited" ? "%20" : style === "pipeDelimited" ? "|" : ",";
+      parts.push(key + "=" + items.join(separator));
+      continue;
+    }
+    if (typeof value === "object") {
+      const entries = Object.entries(value).map(([k, v]) => [encodeURIComponent(k), encodeURIComponent(String(v))]);
+      if (style === "deepObject") {
+        for (const [k, v] of entries) {
+          parts.push(key + "[" + k + "]=" + v);
+        }
+      } else if (explode) {
+        for (const [k, v] of entries) {
+          parts.push(k + "=" + v);
+        }
+      } else {
+        parts.push(key + "=" + entries.flat().join(","));
+      }
+      continue;
+    }
+    parts.push(key + "=" + encodeURIComponent(String(value)));
+  }
+  return parts.join("&");
+}
+
+function isRawBody(body: unknown): body is BodyInit {
+  return (
+    typeof body === "string" ||
+    (typeof Blob !== "undefined" && body instanceof Blob) ||
+    (typeof FormData !== "undefined" && body instanceof FormData) ||
+    (typeof URLSearchParams !== "undefined" && body instanceof URLSearchParams) ||
+    body instanceof ArrayBuffer ||
+    ArrayBuffer.isView(body)
+  );
+}
+
+async function readBody(response: Response): Promise<unknown> {
+  if (response.status === 204 || response.headers.get("content-length") === "0") {
+    return undefined;
+  }
+  const contentType = response.headers.get("content-type") ?? "";
+  if (contentType.includes("json")) {
+    return response.json();
+  }
+  if (contentType === "" || contentType.startsWith("text/")) {
+    return response.text();
+  }
+  return response.blob();
+}
+`
+
+const clientRequest = `  const baseUrl = (options.baseUrl ?? "").replace(/\/+$/, "");
+  const fetchImpl = options.fetch ?? globalThis.fetch;
+
+  async function request<R>(method: HttpMethod, path: string, args?: unknown): Promise<R> {
+    const opts = (args ?? {}) as RawRequestOptions;
+    const serialization = paramSerialization[path]?.[method] ?? {};
+    let url = baseUrl + buildPath(path, opts.params ?? {}, serialization.path ?? {});
+    const query = buildQuery(opts.query ?? {}, serialization.query ?? {});
+    if (query !== "") {
+      url += "?" + query;
+    }
+
+    const headers = new Headers(options.headers);
+    for (const [name, value] of Object.entries(opts.headers ?? {})) {
+      if (value !== undefined && value !== null) {
+        headers.set(name, String(value));
+      }
+    }
+
+    let body: BodyInit | undefined;
+    if (opts.body !== undefined) {
+      if (isRawBody(opts.body)) {
+        body = opts.body;
+      } else {
+        body = JSON.stringify(opts.body);
+        if (!headers.has("content-type")) {
+          headers.set("content-type", "application/json");
+        }
+      }
+    }
+
+    const response = await fetchImpl(url, { ...opts.init, method: method.toUpperCase(), headers, body });
+    return { status: response.status, data: await readBody(response), response } as R;
+  }
+`
+
+func EmitClientFromIR(ir *IR, typesImport string) string {
+	return EmitClientFromIRAt(ir, time.Now(), "", "", typesImport)
+}
+
+func EmitClientFromIRAt(ir *IR, generatedAt time.Time, cliVersion, openAPIVersion, typesImport string) string {
+	var b strings.Builder
+
+	generator := "openapi-tsgen"
+	if cliVersion != "" {
+		generator = generator + "@" + cliVersion
+	}
+
+	b.WriteString(GeneratedHeader(generator, openAPIVersion, generatedAt))
+	b.WriteString("import type { Routes } from " + strconv.Quote(typesImport) + ";\n\n")
+	b.WriteString(clientTypes)
+	b.WriteString("\n")
+	writeParamSerialization(&b, ir)
+	b.WriteString(clientRuntime)
+	b.WriteString("\n")
+	writeCreateClient(&b, ir)
+	return b.String()
+}
+
+func writeParamSerialization(b *strings.Builder, ir *IR) {
+	b.WriteString("const paramSerialization: Record<string, Partial<Record<HttpMethod, OperationSerialization>>> = {")
+
+	paths := sortedPathKeys(ir.Paths)
+	wrote := false
+	for _, path := range paths {
+		item := ir.Paths[path]
+		var pb strings.Builder
+		for _, method := range sortedOpKeys(item.Ops) {
+			op := item.Ops[method]
+			pathStyles := paramSerializationEntries(op.PathParams, "path")
+			queryStyles := paramSerializationEntries(op.QueryParams, "query")
+			if pathStyles == "" && queryStyles == "" {
+				continue
+			}
+			pb.WriteString("    " + method + ": {\n")
+			if pathStyles != "" {
+				pb.WriteString("      path: {\n" + pathStyles + "      },\n")
+			}
+			if queryStyles != "" {
+				pb.WriteString("      query: {\n" + queryStyles + "      },\n")
+			}
+			pb.WriteString("    },\n")
+		}
+		if pb.Len() == 0 {
+			continue
+		}
+		if !wrote {
+			b.WriteString("\n")
+			wrote = true
+		}
+		b.WriteString("  " + strconv.Quote(path) + ": {\n" + pb.String() + "  },\n")
+	}
+	b.WriteString("};\n\n")
+}
+
+func paramSerializationEntries(params map[string]IRParam, in string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		style, explode := paramStyle(params[k], in)
+		defaultStyle, defaultExplode := paramStyle(IRParam{}, in)
+		if style == defaultStyle && explode == defaultExplode {
+			continue
+		}
+		b.WriteString("        " + safeTSKey(k) + ": { style: " + strconv.Quote(style) + ", explode: " + strconv.FormatBool(explode) + " },\n")
+	}
+	return b.String()
+}
+
+func paramStyle(p IRParam, in string) (style string, explode bool) {
+	style = p.Style
+	if style == "" {
+		style = "simple"
+		if in == "query" || in == "cookie" {
+			style = "form"
+		}
+	}
+	explode = style == "form"
+	if p.Explode != nil {
+		explode = *p.Explode
+	}
+	return style, explode
+}
+
+func writeCreateClient(b *strings.Builder, ir *IR) {
+	methods := map[string]bool{}
+	for _, item := range ir.Paths {
+		for m := range item.Ops {
+			methods[m] = true
+		}
+	}
+	names := make([]string, 0, len(methods))
+	for m := range methods {
+		names = append(names, m)
+	}
+	sort.Strings(names)
+
+	b.WriteString("export function createClient(options: ClientOptions = {}) {\n")
+	b.WriteString(clientRequest)
+	b.WriteString("\n  return {\n")
+	for _, m := range names {
+		q := strconv.Quote(m)
+		b.WriteString("    " + m + ": <P extends RoutePath<" + q + ">>(path: P, ...args: RequestArgs<RouteOperation<P, " + q + ">>) =>\n")
+		b.WriteString("      request<ApiResponse<RouteOperation<P, " + q + ">>>(" + q + ", path, args[0]),\n")
+	}
+	b.WriteString("  };\n")
+	b.WriteString("}\n\n")
+	b.WriteString("export type Client = ReturnType<typeof createClient>;\n")
+}
+
+func sortedPathKeys(items map[string]IRPathItem) []string {
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedOpKeys(ops map[string]IROperation) []string {
+	keys := make([]string, 0, len(ops))
+	for k := range ops {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,14 +1,17 @@
 package schema
 
 const (
-	tsNever          = "never"
-	tsUnknown        = "unknown"
-	tsRecordUnknown  = "Record<string, unknown>"
-	tsEmptyObject    = "{}"
-	schemaTypeString = "string"
-	schemaTypeNull   = "null"
-	enumValuePrefix  = "Value"
-	enumNumberPrefix = "VALUE_"
+	tsNever           = "never"
+	tsUnknown         = "unknown"
+	tsEmptyObject     = "{}"
+	schemaTypeString  = "string"
+	schemaTypeNull    = "null"
+	schemaTypeInteger = "integer"
+	enumValuePrefix   = "Value"
+	enumNumberPrefix  = "VALUE_"
+	maxSchemaDepth    = 30
+	maxFixedTuple     = 8
+	generatedWarning  = " * @Warning: THIS FILE IS AUTO-GENERATED - DO NOT EDIT\n"
 )
 
 const (
@@ -22,3 +25,58 @@ const (
 	enumString
 	enumNumber
 )
+
+const (
+	TypeUnknown TypeKind = iota
+	TypeNever
+	TypePrimitive
+	TypeLiteral
+	TypeTemplate
+	TypeArray
+	TypeTuple
+	TypeObject
+	TypeRecord
+	TypeMapped
+	TypeUnion
+	TypeIntersection
+	TypeOmit
+	TypeRef
+	TypeEnum
+	TypeOptional
+	TypeRest
+	TypeBrand
+)
+
+const (
+	kindOther nodeKind = iota
+	kindLiteral
+	kindDocument
+	kindComponents
+	kindPathMap
+	kindPathItem
+	kindOperation
+	kindParameterList
+	kindSchema
+	kindSchemaMap
+	kindSchemaList
+	kindResponse
+	kindResponseMap
+	kindParameter
+	kindParameterMap
+	kindRequestBody
+	kindRequestBodyMap
+	kindHeader
+	kindHeaderMap
+	kindSecurityScheme
+	kindSecuritySchemeMap
+	kindExample
+	kindExampleMap
+	kindLink
+	kindLinkMap
+	kindCallback
+	kindCallbackMap
+	kindMediaType
+	kindContentMap
+	kindEncoding
+	kindEncodingMap
+)
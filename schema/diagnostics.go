@@ -0,0 +1,86 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrStrictDiagnostics = errors.New("diagnostics reported in strict mode")
+
+type Diagnostic struct {
+	Pointer string
+	Reason  string
+}
+
+func (d Diagnostic) String() string {
+	return d.Pointer + ": " + d.Reason
+}
+
+type diagnostics struct {
+	seen   map[Diagnostic]bool
+	path   []string
+	origin []string
+	items  []Diagnostic
+}
+
+func newDiagnostics() *diagnostics {
+	return &diagnostics{seen: map[Diagnostic]bool{}}
+}
+
+func (c *enumContext) enter(tokens ...string) func() {
+	if c == nil || c.diags == nil {
+		return func() {}
+	}
+	n := len(c.diags.path)
+	c.diags.path = append(c.diags.path, tokens...)
+	return func() { c.diags.path = c.diags.path[:n] }
+}
+
+func (c *enumContext) enterAt(tokens ...string) func() {
+	if c == nil || c.diags == nil {
+		return func() {}
+	}
+	prev := c.diags.path
+	c.diags.path = append([]string(nil), tokens...)
+	return func() { c.diags.path = prev }
+}
+
+func (c *enumContext) enterComponent(section, name string) func() {
+	if c == nil || c.diags == nil {
+		return func() {}
+	}
+	prev, origin := c.diags.path, c.diags.origin
+	if origin == nil {
+		c.diags.origin = prev
+	}
+	c.diags.path = []string{"components", section, name}
+	return func() { c.diags.path, c.diags.origin = prev, origin }
+}
+
+func (c *enumContext) warnDepth() {
+	if c == nil || c.diags == nil {
+		return
+	}
+	if c.diags.origin != nil {
+		defer c.enterAt(c.diags.origin...)()
+	}
+	c.warn("schema nested deeper than %d levels, emitted unknown", maxSchemaDepth)
+}
+
+func (c *enumContext) warn(format string, args ...any) {
+	if c == nil || c.diags == nil {
+		return
+	}
+	var b strings.Builder
+	b.WriteString("#")
+	for _, tok := range c.diags.path {
+		b.WriteString("/" + escapePointerToken(tok))
+	}
+	d := Diagnostic{Pointer: b.String(), Reason: fmt.Sprintf(format, args...)}
+	if c.diags.seen[d] {
+		return
+	}
+	c.diags.seen[d] = true
+	c.diags.items = append(c.diags.items, d)
+}
@@ -0,0 +1,617 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var ErrUnknownDiffFormat = errors.New("unknown diff format")
+
+const (
+	DiffText     = "text"
+	DiffJSON     = "json"
+	DiffMarkdown = "markdown"
+)
+
+type Change struct {
+	Location string `json:"location"`
+	Message  string `json:"message"`
+	Breaking bool   `json:"breaking"`
+}
+
+type differ struct {
+	oldIR    *IR
+	newIR    *IR
+	visiting map[[2]*TypeNode]bool
+	location string
+	changes  []Change
+}
+
+func DiffFiles(oldPath, newPath string, format InputFormat) ([]Change, error) {
+	oldIR, err := loadIR(oldPath, format)
+	if err != nil {
+		return nil, err
+	}
+	newIR, err := loadIR(newPath, format)
+	if err != nil {
+		return nil, err
+	}
+	return DiffIR(oldIR, newIR), nil
+}
+
+func loadIR(path string, format InputFormat) (*IR, error) {
+	doc, err := LoadDocument(path, format)
+	if err != nil {
+		return nil, err
+	}
+	ir, err := ToIR(doc)
+	if err != nil {
+		return nil, fmt.Errorf("build IR for %q: %w", path, err)
+	}
+	return ir, nil
+}
+
+func DiffIR(oldIR, newIR *IR) []Change {
+	d := &differ{oldIR: oldIR, newIR: newIR, visiting: map[[2]*TypeNode]bool{}}
+	d.diffPathItems("", oldIR.Paths, newIR.Paths, "route")
+	d.diffPathItems("webhook ", oldIR.Webhooks, newIR.Webhooks, "webhook")
+	return d.changes
+}
+
+func BreakingChanges(changes []Change) int {
+	n := 0
+	for _, c := range changes {
+		if c.Breaking {
+			n++
+		}
+	}
+	return n
+}
+
+func (d *differ) add(breaking bool, format string, args ...any) {
+	d.changes = append(d.changes, Change{
+		Location: d.location,
+		Message:  fmt.Sprintf(format, args...),
+		Breaking: breaking,
+	})
+}
+
+func (d *differ) diffPathItems(prefix string, oldItems, newItems map[string]IRPathItem, noun string) {
+	for _, key := range unionKeys(oldItems, newItems) {
+		oldItem, inOld := oldItems[key]
+		newItem, inNew := newItems[key]
+		d.location = prefix + key
+		switch {
+		case !inNew:
+			d.add(true, "%s removed", noun)
+			continue
+		case !inOld:
+			d.add(false, "%s added", noun)
+			continue
+		}
+		for _, method := range unionKeys(oldItem.Ops, newItem.Ops) {
+			oldOp, inOld := oldItem.Ops[method]
+			newOp, inNew := newItem.Ops[method]
+			d.location = prefix + strings.ToUpper(method) + " " + key
+			switch {
+			case !inNew:
+				d.add(true, "operation removed")
+			case !inOld:
+				d.add(false, "operation added")
+			default:
+				d.diffOperation(oldOp, newOp)
+			}
+		}
+	}
+}
+
+func (d *differ) diffOperation(oldOp, newOp IROperation) {
+	d.diffParams("path", oldOp.PathParams, newOp.PathParams)
+	d.diffParams("query", oldOp.QueryParams, newOp.QueryParams)
+	d.diffParams("header", oldOp.HeaderParams, newOp.HeaderParams)
+	d.diffParams("cookie", oldOp.CookieParams, newOp.CookieParams)
+
+	switch {
+	case oldOp.RequestBody == nil && newOp.RequestBody != nil && newOp.BodyRequired:
+		d.add(true, "required request body added")
+	case oldOp.RequestBody == nil && newOp.RequestBody != nil:
+		d.add(false, "optional request body added")
+	case oldOp.RequestBody != nil && newOp.RequestBody == nil:
+		d.add(false, "request body removed")
+	case oldOp.RequestBody != nil:
+		if !oldOp.BodyRequired && newOp.BodyRequired {
+			d.add(true, "request body became required")
+		} else if oldOp.BodyRequired && !newOp.BodyRequired {
+			d.add(false, "request body became optional")
+		}
+		d.diffType("request body", oldOp.RequestBody, newOp.RequestBody, modeInput)
+	}
+
+	for _, code := range unionKeys(oldOp.Responses, newOp.Responses) {
+		oldResp, inOld := oldOp.Responses[code]
+		newResp, inNew := newOp.Responses[code]
+		switch {
+		case !inNew:
+			d.add(true, "response %s removed", code)
+		case !inOld:
+			d.add(false, "response %s added", code)
+		default:
+			d.diffType("response "+code, oldResp, newResp, modeOutput)
+		}
+	}
+}
+
+func (d *differ) diffParams(in string, oldParams, newParams map[string]IRParam) {
+	for _, name := range unionKeys(oldParams, newParams) {
+		oldP, inOld := oldParams[name]
+		newP, inNew := newParams[name]
+		label := fmt.Sprintf("%s parameter %q", in, name)
+		switch {
+		case !inNew:
+			d.add(false, "%s removed", label)
+		case !inOld && newP.Required:
+			d.add(true, "required %s added", label)
+		case !inOld:
+			d.add(false, "optional %s added", label)
+		default:
+			if !oldP.Required && newP.Required {
+				d.add(true, "%s became required", label)
+			} else if oldP.Required && !newP.Required {
+				d.add(false, "%s became optional", label)
+			}
+			d.diffType(label, oldP.Type, newP.Type, modeInput)
+		}
+	}
+}
+
+func (d *differ) diffType(path string, oldT, newT *TypeNode, mode schemaMode) {
+	oldT, newT = d.resolve(d.oldIR, oldT), d.resolve(d.newIR, newT)
+	if oldT == nil || newT == nil || typeKey(normalizeType(d.oldIR, oldT)) == typeKey(normalizeType(d.newIR, newT)) {
+		return
+	}
+	pair := [2]*TypeNode{oldT, newT}
+	if d.visiting[pair] {
+		return
+	}
+	d.visiting[pair] = true
+	defer delete(d.visiting, pair)
+
+	oldBase, oldNull := splitNullable(oldT)
+	newBase, newNull := splitNullable(newT)
+	if oldNull != newNull {
+		if newNull {
+			d.add(mode == modeOutput, "%s: became nullable", path)
+		} else {
+			d.add(mode == modeInput, "%s: no longer nullable", path)
+		}
+		d.diffType(path, oldBase, newBase, mode)
+		return
+	}
+
+	switch {
+	case oldT.Kind == TypeNever:
+		d.add(false, "%s: body added", path)
+		return
+	case newT.Kind == TypeNever:
+		d.add(true, "%s: body removed", path)
+		return
+	case mode == modeInput && newT.Kind == TypeUnknown:
+		d.add(false, "%s: type relaxed to unknown", path)
+		return
+	}
+
+	if oldVals, ok := d.literalSet(d.oldIR, oldT); ok {
+		if newVals, ok := d.literalSet(d.newIR, newT); ok {
+			d.diffLiterals(path, oldVals, newVals, mode)
+			return
+		}
+	}
+
+	if oldT.Kind == TypeIntersection || newT.Kind == TypeIntersection {
+		oldFields, oldOK := d.mergedFields(d.oldIR, oldT, 0)
+		newFields, newOK := d.mergedFields(d.newIR, newT, 0)
+		if oldOK && newOK {
+			d.diffFields(path, oldFields, newFields, mode)
+			return
+		}
+	}
+
+	switch {
+	case oldT.Kind == TypeUnion && newT.Kind == TypeUnion:
+		d.diffUnion(path, oldT.Items, newT.Items, mode)
+	case oldT.Kind == TypeObject && newT.Kind == TypeObject:
+		d.diffFields(path, oldT.Fields, newT.Fields, mode)
+	case oldT.Kind == TypeArray && newT.Kind == TypeArray:
+		d.diffType(path+"[]", oldT.Elem, newT.Elem, mode)
+	case oldT.Kind == TypeRecord && newT.Kind == TypeRecord:
+		d.diffType(path+"[key]", oldT.Elem, newT.Elem, mode)
+	default:
+		oldN, newN := normalizeType(d.oldIR, oldT), normalizeType(d.newIR, newT)
+		switch {
+		case widensTo(oldN, newN):
+			d.add(mode == modeOutput, "%s: type widened from %s to %s", path, typeSummary(oldN), typeSummary(newN))
+		case widensTo(newN, oldN):
+			d.add(mode == modeInput, "%s: type narrowed from %s to %s", path, typeSummary(oldN), typeSummary(newN))
+		default:
+			d.add(true, "%s: type changed from %s to %s", path, typeSummary(oldN), typeSummary(newN))
+		}
+	}
+}
+
+func (d *differ) diffFields(path string, oldFields, newFields []Field, mode schemaMode) {
+	oldByName := make(map[string]Field, len(oldFields))
+	for _, f := range oldFields {
+		oldByName[f.Name] = f
+	}
+	newByName := make(map[string]Field, len(newFields))
+	for _, f := range newFields {
+		newByName[f.Name] = f
+	}
+
+	for _, name := range unionKeys(oldByName, newByName) {
+		oldF, inOld := oldByName[name]
+		newF, inNew := newByName[name]
+		label := path + "." + name
+		switch {
+		case !inNew:
+			d.add(mode == modeOutput, "%s: field removed", label)
+		case !inOld && mode == modeInput && !newF.Optional:
+			d.add(true, "%s: required field added", label)
+		case !inOld:
+			d.add(false, "%s: field added", label)
+		default:
+			if oldF.Optional && !newF.Optional {
+				d.add(mode == modeInput, "%s: field became required", label)
+			} else if !oldF.Optional && newF.Optional {
+				d.add(mode == modeOutput, "%s: field became optional", label)
+			}
+			d.diffType(label, oldF.Type, newF.Type, mode)
+		}
+	}
+}
+
+func (d *differ) mergedFields(ir *IR, t *TypeNode, depth int) ([]Field, bool) {
+	t = d.resolve(ir, t)
+	if t == nil || depth > maxSchemaDepth {
+		return nil, false
+	}
+	switch t.Kind {
+	case TypeObject:
+		return t.Fields, true
+	case TypeIntersection:
+		var out []Field
+		index := map[string]int{}
+		for _, it := range t.Items {
+			fields, ok := d.mergedFields(ir, it, depth+1)
+			if !ok {
+				return nil, false
+			}
+			for _, f := range fields {
+				i, seen := index[f.Name]
+				if !seen {
+					index[f.Name] = len(out)
+					out = append(out, f)
+					continue
+				}
+				merged := out[i]
+				merged.Optional = merged.Optional && f.Optional
+				if typeKey(merged.Type) != typeKey(f.Type) {
+					merged.Type = intersectionOf(merged.Type, f.Type)
+				}
+				out[i] = merged
+			}
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
+func (d *differ) diffUnion(path string, oldItems, newItems []*TypeNode, mode schemaMode) {
+	newMatched := make([]bool, len(newItems))
+	var oldRest, newRest []*TypeNode
+	for _, o := range oldItems {
+		key := typeKey(normalizeType(d.oldIR, o))
+		matched := false
+		for j, n := range newItems {
+			if !newMatched[j] && typeKey(normalizeType(d.newIR, n)) == key {
+				newMatched[j], matched = true, true
+				d.diffType(path, o, n, mode)
+				break
+			}
+		}
+		if !matched {
+			oldRest = append(oldRest, o)
+		}
+	}
+	for j, n := range newItems {
+		if !newMatched[j] {
+			newRest = append(newRest, n)
+		}
+	}
+
+	if len(oldRest) == len(newRest) {
+		for i := range oldRest {
+			d.diffType(path, oldRest[i], newRest[i], mode)
+		}
+		return
+	}
+	for _, o := range oldRest {
+		d.add(mode == modeInput, "%s: variant %s removed", path, typeSummary(normalizeType(d.oldIR, o)))
+	}
+	for _, n := range newRest {
+		d.add(mode == modeOutput, "%s: variant %s added", path, typeSummary(normalizeType(d.newIR, n)))
+	}
+}
+
+func (d *differ) diffLiterals(path string, oldVals, newVals []string, mode schemaMode) {
+	oldSet := make(map[string]bool, len(oldVals))
+	for _, v := range oldVals {
+		oldSet[v] = true
+	}
+	newSet := make(map[string]bool, len(newVals))
+	for _, v := range newVals {
+		newSet[v] = true
+	}
+	for _, v := range oldVals {
+		if !newSet[v] {
+			d.add(mode == modeInput, "%s: value %s removed", path, v)
+		}
+	}
+	for _, v := range newVals {
+		if !oldSet[v] {
+			d.add(mode == modeOutput, "%s: value %s added", path, v)
+		}
+	}
+}
+
+func (d *differ) resolve(ir *IR, t *TypeNode) *TypeNode {
+	for i := 0; t != nil && t.Kind == TypeRef && i < maxSchemaDepth; i++ {
+		var next *TypeNode
+		switch t.Section {
+		case "schemas":
+			next = ir.ComponentsSchemas[t.Name]
+		case "responses":
+			next = ir.ComponentsResponses[t.Name]
+		case "requestBodies":
+			next = ir.ComponentsRequestBody[t.Name]
+		case "parameters":
+			next = ir.ComponentsParameters[t.Name]
+		case "headers":
+			next = ir.ComponentsHeaders[t.Name]
+		}
+		if next == nil {
+			return t
+		}
+		t = next
+	}
+	return t
+}
+
+func (d *differ) literalSet(ir *IR, t *TypeNode) ([]string, bool) {
+	switch t.Kind {
+	case TypeLiteral:
+		return []string{RenderTS(t)}, true
+	case TypeEnum:
+		e, ok := ir.Enums[t.Name]
+		if !ok {
+			return nil, false
+		}
+		out := make([]string, 0, len(e.Members))
+		for _, m := range e.Members {
+			out = append(out, literalToTS(m.Value))
+		}
+		return out, true
+	case TypeUnion:
+		var out []string
+		for _, it := range t.Items {
+			vals, ok := d.literalSet(ir, it)
+			if !ok {
+				return nil, false
+			}
+			out = append(out, vals...)
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
+func normalizeType(ir *IR, t *TypeNode) *TypeNode {
+	if t == nil {
+		return nil
+	}
+	if t.Kind == TypeEnum {
+		e, ok := ir.Enums[t.Name]
+		if !ok {
+			return t
+		}
+		items := make([]*TypeNode, 0, len(e.Members))
+		for _, m := range e.Members {
+			items = append(items, literalType(m.Value))
+		}
+		return unionOf(items...)
+	}
+	if isPrimitive(t, "number") && t.Schema["type"] == schemaTypeInteger {
+		return primitiveType(schemaTypeInteger)
+	}
+	cp := *t
+	cp.Elem = normalizeType(ir, t.Elem)
+	cp.Key = normalizeType(ir, t.Key)
+	if len(t.Items) > 0 {
+		cp.Items = make([]*TypeNode, len(t.Items))
+		for i, it := range t.Items {
+			cp.Items[i] = normalizeType(ir, it)
+		}
+	}
+	if len(t.Fields) > 0 {
+		cp.Fields = make([]Field, len(t.Fields))
+		for i, f := range t.Fields {
+			f.Type = normalizeType(ir, f.Type)
+			cp.Fields[i] = f
+		}
+	}
+	return &cp
+}
+
+func widensTo(from, to *TypeNode) bool {
+	if to.Kind != TypePrimitive {
+		return false
+	}
+	switch from.Kind {
+	case TypePrimitive:
+		return from.Name == schemaTypeInteger && to.Name == "number"
+	case TypeLiteral:
+		return literalBase(from.Literal) == to.Name || literalBase(from.Literal) == schemaTypeInteger && to.Name == "number"
+	case TypeUnion:
+		for _, it := range from.Items {
+			if !widensTo(it, to) {
+				return false
+			}
+		}
+		return len(from.Items) > 0
+	default:
+		return false
+	}
+}
+
+func literalBase(v any) string {
+	switch val := v.(type) {
+	case string:
+		return schemaTypeString
+	case bool:
+		return "boolean"
+	case int, int32, int64:
+		return schemaTypeInteger
+	case float32:
+		if float32(int64(val)) == val {
+			return schemaTypeInteger
+		}
+		return "number"
+	case float64:
+		if float64(int64(val)) == val {
+			return schemaTypeInteger
+		}
+		return "number"
+	default:
+		return ""
+	}
+}
+
+func splitNullable(t *TypeNode) (*TypeNode, bool) {
+	if t.Kind != TypeUnion {
+		return t, false
+	}
+	rest := make([]*TypeNode, 0, len(t.Items))
+	for _, it := range t.Items {
+		if !isPrimitive(it, schemaTypeNull) {
+			rest = append(rest, it)
+		}
+	}
+	if len(rest) == len(t.Items) {
+		return t, false
+	}
+	if len(rest) == 1 {
+		return rest[0], true
+	}
+	return unionOf(rest...), true
+}
+
+func typeSummary(t *TypeNode) string {
+	switch t.Kind {
+	case TypeObject:
+		return "object"
+	case TypeArray:
+		return typeSummary(t.Elem) + "[]"
+	default:
+		if s := RenderTS(t); !strings.Contains(s, "\n") {
+			return s
+		}
+		return "complex type"
+	}
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func FormatChanges(changes []Change, format string) (string, error) {
+	switch format {
+	case DiffText, "":
+		return formatChangesText(changes), nil
+	case DiffJSON:
+		if changes == nil {
+			changes = []Change{}
+		}
+		out, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("encode changes: %w", err)
+		}
+		return string(out) + "\n", nil
+	case DiffMarkdown:
+		return formatChangesMarkdown(changes), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownDiffFormat, format)
+	}
+}
+
+func formatChangesText(changes []Change) string {
+	if len(changes) == 0 {
+		return "no API changes\n"
+	}
+	var b strings.Builder
+	for _, c := range changes {
+		tag := "non-breaking"
+		if c.Breaking {
+			tag = "BREAKING"
+		}
+		fmt.Fprintf(&b, "%-12s %s: %s\n", tag, c.Location, c.Message)
+	}
+	fmt.Fprintf(&b, "\n%d change(s), %d breaking\n", len(changes), BreakingChanges(changes))
+	return b.String()
+}
+
+func formatChangesMarkdown(changes []Change) string {
+	var b strings.Builder
+	b.WriteString("## API changes\n\n")
+	if len(changes) == 0 {
+		b.WriteString("No API changes.\n")
+		return b.String()
+	}
+	for _, section := range []struct {
+		title    string
+		breaking bool
+	}{
+		{title: "Breaking changes", breaking: true},
+		{title: "Non-breaking changes", breaking: false},
+	} {
+		var rows []Change
+		for _, c := range changes {
+			if c.Breaking == section.breaking {
+				rows = append(rows, c)
+			}
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "### %s (%d)\n\n", section.title, len(rows))
+		for _, c := range rows {
+			fmt.Fprintf(&b, "- `%s`: %s\n", c.Location, c.Message)
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
@@ -0,0 +1,138 @@
+package schema
+
+import (
+	"sort"
+	"strings"
+)
+
+func discriminatedUnion(doc *Document, o map[string]any, items []any, union *TypeNode) *TypeNode {
+	d, _ := o["discriminator"].(map[string]any)
+	prop, _ := d["propertyName"].(string)
+	if prop == "" || union.Kind != TypeUnion {
+		return union
+	}
+	mapping := discriminatorMapping(d)
+	for i, it := range items {
+		values := discriminatorValues(doc, it, prop, mapping)
+		if len(values) == 0 {
+			continue
+		}
+		union.Items[i] = intersectionOf(
+			omitType(union.Items[i], literalType(prop)),
+			objectType([]Field{{Name: prop, Type: literalUnion(values)}}),
+		)
+	}
+	return union
+}
+
+func discriminatorMapping(d map[string]any) map[string][]string {
+	raw, _ := d["mapping"].(map[string]any)
+	if len(raw) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := map[string][]string{}
+	for _, k := range keys {
+		target, ok := raw[k].(string)
+		if !ok {
+			continue
+		}
+		name, ok := refComponentName(target, "schemas")
+		if !ok {
+			if strings.Contains(target, "/") {
+				continue
+			}
+			name = target
+		}
+		out[name] = append(out[name], k)
+	}
+	return out
+}
+
+func discriminatorValues(doc *Document, item any, prop string, mapping map[string][]string) []*TypeNode {
+	m, ok := item.(map[string]any)
+	if !ok {
+		return nil
+	}
+	ref, _ := m["$ref"].(string)
+	if ref == "" {
+		return schemaPropertyLiterals(doc, m, prop, 0)
+	}
+	name, ok := refComponentName(ref, "schemas")
+	if !ok {
+		return nil
+	}
+	if values := mapping[name]; len(values) > 0 {
+		out := make([]*TypeNode, 0, len(values))
+		for _, v := range values {
+			out = append(out, literalType(v))
+		}
+		return out
+	}
+	if lits := schemaPropertyLiterals(doc, componentSchemaMap(doc, name), prop, 0); len(lits) > 0 {
+		return lits
+	}
+	return []*TypeNode{literalType(name)}
+}
+
+func schemaPropertyLiterals(doc *Document, o map[string]any, prop string, depth int) []*TypeNode {
+	if o == nil || depth > 30 {
+		return nil
+	}
+	if ref, ok := o["$ref"].(string); ok {
+		if name, ok := refComponentName(ref, "schemas"); ok {
+			return schemaPropertyLiterals(doc, componentSchemaMap(doc, name), prop, depth+1)
+		}
+		return nil
+	}
+	props, _ := o["properties"].(map[string]any)
+	if lits := propertyEnumLiterals(props, prop); len(lits) > 0 {
+		return lits
+	}
+	for _, it := range anySlice(o["allOf"]) {
+		m, _ := it.(map[string]any)
+		if lits := schemaPropertyLiterals(doc, m, prop, depth+1); len(lits) > 0 {
+			return lits
+		}
+	}
+	return nil
+}
+
+func componentSchemaMap(doc *Document, name string) map[string]any {
+	if doc == nil || doc.Components == nil {
+		return nil
+	}
+	sch, ok := doc.Components.Schemas[name]
+	if !ok {
+		return nil
+	}
+	return schemaMap(&sch)
+}
+
+func schemaMap(s *Schema) map[string]any {
+	if s.Discriminator == nil {
+		return s.Other
+	}
+	if _, ok := s.Other["discriminator"]; ok {
+		return s.Other
+	}
+	o := make(map[string]any, len(s.Other)+1)
+	for k, v := range s.Other {
+		o[k] = v
+	}
+	d := map[string]any{"propertyName": s.Discriminator.PropertyName}
+	if len(s.Discriminator.Mapping) > 0 {
+		mapping := make(map[string]any, len(s.Discriminator.Mapping))
+		for k, v := range s.Discriminator.Mapping {
+			mapping[k] = v
+		}
+		d["mapping"] = mapping
+	}
+	o["discriminator"] = d
+	return o
+}
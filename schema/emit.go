@@ -11,7 +11,7 @@ func GeneratedHeader(generator, openAPIVersion string, generatedAt time.Time) st
 	var b strings.Builder
 
 	b.WriteString(headerStart())
-	b.WriteString(" * @Warning: THIS FILE IS AUTO-GENERATED - DO NOT EDIT\n")
+	b.WriteString(generatedWarning)
 	b.WriteString(" *\n")
 	b.WriteString(" * Generator: " + generator + "\n")
 	if openAPIVersion != "" {
@@ -27,28 +27,59 @@ func headerStart() string {
 	return "/*\n"
 }
 
+func isGeneratedOutput(s string) bool {
+	return strings.HasPrefix(s, headerStart()+generatedWarning)
+}
+
 func EmitTypesFromIR(ir *IR) string {
 	return EmitTypesFromIRAt(ir, time.Now(), "", "")
 }
 
-func EmitTypesFromIRAt(ir *IR, generatedAt time.Time, cliVersion, openAPIVersion string) string {
-	var b strings.Builder
+type EmitOptions struct {
+	ComponentAliasPrefix string
+	ComponentAliasSuffix string
+	EnumStyle            EnumStyle
+	ComponentAliases     bool
+	OperationAliases     bool
+}
 
-	generator := "openapi-tsgen"
-	if cliVersion != "" {
-		generator = generator + "@" + cliVersion
-	}
+func EmitTypesFromIRAt(ir *IR, generatedAt time.Time, cliVersion, openAPIVersion string) string {
+	return EmitTypesFromIRWith(ir, generatedAt, cliVersion, openAPIVersion, EmitOptions{})
+}
 
-	b.WriteString(GeneratedHeader(generator, openAPIVersion, generatedAt))
+func EmitTypesFromIRWith(ir *IR, generatedAt time.Time, cliVersion, openAPIVersion string, opts EmitOptions) string {
+	var b strings.Builder
+	b.WriteString(GeneratedHeader(generatorName(cliVersion), openAPIVersion, generatedAt))
 
-	writeEnums(&b, ir)
+	writeEnums(&b, ir, opts.EnumStyle)
+	writeBrands(&b, ir)
 	writeServers(&b, ir)
+	names := reservedNames(ir)
 	writeComponents(&b, ir)
+	if opts.ComponentAliases {
+		writeComponentAliases(&b, ir, opts, names)
+	}
 	writeRoutes(&b, ir)
+	writeOperations(&b, "Operations", "Routes", ir.Operations, operationAliases(ir, opts, names), ir.Paths)
 	writeWebhooks(&b, ir)
 	return b.String()
 }
 
+func generatorName(cliVersion string) string {
+	if cliVersion == "" {
+		return "openapi-tsgen"
+	}
+	return "openapi-tsgen@" + cliVersion
+}
+
+func reservedNames(ir *IR) map[string]bool {
+	names := newEnumContext(ir.Enums).used
+	for name := range ir.Brands {
+		names[name] = true
+	}
+	return names
+}
+
 func writeComponents(b *strings.Builder, ir *IR) {
 	if len(ir.ComponentsSchemas) == 0 &&
 		len(ir.ComponentsResponses) == 0 &&
@@ -60,16 +91,70 @@ func writeComponents(b *strings.Builder, ir *IR) {
 	}
 
 	b.WriteString("export type Components = {\n")
-	writeComponentSection(b, "schemas", ir.ComponentsSchemas)
-	writeComponentSection(b, "responses", ir.ComponentsResponses)
-	writeComponentSection(b, "requestBodies", ir.ComponentsRequestBody)
-	writeComponentSection(b, "parameters", ir.ComponentsParameters)
-	writeComponentSection(b, "headers", ir.ComponentsHeaders)
-	writeComponentSection(b, "securitySchemes", ir.ComponentsSecuritySchemes)
+	writeComponentSection(b, "schemas", ir.ComponentsSchemas, ir.ComponentsDocs["schemas"], ir.order.keys("components", "schemas"))
+	writeComponentSection(b, "responses", ir.ComponentsResponses, ir.ComponentsDocs["responses"], ir.order.keys("components", "responses"))
+	writeComponentSection(b, "requestBodies", ir.ComponentsRequestBody, ir.ComponentsDocs["requestBodies"], ir.order.keys("components", "requestBodies"))
+	writeComponentSection(b, "parameters", ir.ComponentsParameters, ir.ComponentsDocs["parameters"], ir.order.keys("components", "parameters"))
+	writeComponentSection(b, "headers", ir.ComponentsHeaders, ir.ComponentsDocs["headers"], ir.order.keys("components", "headers"))
+	writeComponentSection(b, "securitySchemes", ir.ComponentsSecuritySchemes, nil, ir.order.keys("components", "securitySchemes"))
 	b.WriteString("};\n\n")
 }
 
-func writeEnums(b *strings.Builder, ir *IR) {
+func writeComponentAliases(b *strings.Builder, ir *IR, opts EmitOptions, names map[string]bool) {
+	sections := []struct {
+		values map[string]*TypeNode
+		label  string
+		suffix string
+	}{
+		{ir.ComponentsSchemas, "schemas", ""},
+		{ir.ComponentsResponses, "responses", "Response"},
+		{ir.ComponentsRequestBody, "requestBodies", "RequestBody"},
+		{ir.ComponentsParameters, "parameters", "Parameter"},
+		{ir.ComponentsHeaders, "headers", "Header"},
+	}
+	wrote := false
+	for _, s := range sections {
+		keys := make([]string, 0, len(s.values))
+		for k := range s.values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			ident := k
+			if !isTSIdent(ident) {
+				ident = pascalWords(ident)
+				if opts.ComponentAliasPrefix == "" {
+					ident = prefixLeadingDigit(ident, "Component")
+				}
+			}
+			base := opts.ComponentAliasPrefix + ident + s.suffix + opts.ComponentAliasSuffix
+			if tsReservedTypeNames[base] {
+				base += "Schema"
+			}
+			if t, _ := splitNullable(s.values[k]); t.Kind == TypeBrand && t.Name == base {
+				continue
+			}
+			alias := reserveName(base, names)
+			b.WriteString(jsDoc("", ir.ComponentsDocs[s.label][k]))
+			b.WriteString("export type " + alias + " = Components[" + strconv.Quote(s.label) + "][" + strconv.Quote(k) + "];\n")
+			wrote = true
+		}
+	}
+	if wrote {
+		b.WriteString("\n")
+	}
+}
+
+func reserveName(base string, names map[string]bool) string {
+	name := base
+	for i := 2; names[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	names[name] = true
+	return name
+}
+
+func writeEnums(b *strings.Builder, ir *IR, style EnumStyle) {
 	if len(ir.Enums) == 0 {
 		return
 	}
@@ -79,11 +164,17 @@ func writeEnums(b *strings.Builder, ir *IR) {
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		b.WriteString(ir.Enums[k])
+		b.WriteString(renderTSEnum(ir.Enums[k], style))
+	}
+}
+
+func writeBrands(b *strings.Builder, ir *IR) {
+	for _, br := range sortedBrands(ir.Brands) {
+		b.WriteString(renderTSBrand(br))
 	}
 }
 
-func writeComponentSection(b *strings.Builder, label string, values map[string]string) {
+func writeComponentSection(b *strings.Builder, label string, values map[string]*TypeNode, docs map[string][]string, declared []string) {
 	if len(values) == 0 {
 		return
 	}
@@ -92,10 +183,11 @@ func writeComponentSection(b *strings.Builder, label string, values map[string]s
 	for k := range values {
 		keys = append(keys, k)
 	}
-	sort.Strings(keys)
+	orderKeys(keys, declared, nil)
 	for _, k := range keys {
 		key := safeTSKey(k)
-		writeTSField(b, "    ", key, values[k])
+		b.WriteString(jsDoc("    ", docs[k]))
+		writeTSField(b, "    ", key, RenderTS(values[k]))
 	}
 	b.WriteString("  };\n")
 }
@@ -104,189 +196,273 @@ func writeRoutes(b *strings.Builder, ir *IR) {
 	if len(ir.Paths) == 0 {
 		return
 	}
-	writePathItems(b, "Routes", ir.Paths)
+	writePathItems(b, "Routes", "paths", ir.Paths, ir.order)
+}
+
+func operationAliases(ir *IR, opts EmitOptions, names map[string]bool) []string {
+	if !opts.OperationAliases {
+		return nil
+	}
+	aliases := make([]string, len(ir.Operations))
+	for _, explicit := range []bool{true, false} {
+		for i, op := range ir.Operations {
+			if (ir.Paths[op.Path].Ops[op.Method].OperationID != "") == explicit {
+				aliases[i] = reserveOperationAlias(pascalIdent(op.Name), names)
+			}
+		}
+	}
+	return aliases
+}
+
+func writeOperations(b *strings.Builder, label, routes string, ops []IROperationName, aliases []string, paths map[string]IRPathItem) {
+	if len(ops) == 0 {
+		return
+	}
+	b.WriteString("export type " + label + " = {\n")
+	for _, op := range ops {
+		writeTSField(b, "  ", safeTSKey(op.Name), operationRef(routes, op))
+	}
+	b.WriteString("};\n\n")
+
+	for i, op := range ops {
+		if aliases != nil {
+			writeOperationAliases(b, aliases[i], operationRef(routes, op), paths[op.Path].Ops[op.Method])
+		}
+	}
+}
+
+func writeOperationAliases(b *strings.Builder, alias, ref string, op IROperation) {
+	parts := []struct {
+		params map[string]IRParam
+		suffix string
+		key    string
+	}{
+		{op.PathParams, "Params", "params"},
+		{op.QueryParams, "Query", "query"},
+		{op.HeaderParams, "Headers", "headers"},
+		{op.CookieParams, "Cookies", "cookies"},
+	}
+	for _, p := range parts {
+		if len(p.params) > 0 {
+			b.WriteString("export type " + alias + p.suffix + " = " + ref + "[" + strconv.Quote(p.key) + "];\n")
+		}
+	}
+	if op.RequestBody != nil {
+		b.WriteString("export type " + alias + "Body = " + ref + "[\"requestBody\"];\n")
+	}
+	b.WriteString("export type " + alias + "Response = " + successResponses(ref, op.Responses) + ";\n\n")
+}
+
+func operationRef(routes string, op IROperationName) string {
+	return routes + "[" + strconv.Quote(op.Path) + "][" + strconv.Quote(op.Method) + "]"
+}
+
+func successResponses(ref string, responses map[string]*TypeNode) string {
+	codes := []string{}
+	for c := range responses {
+		if n, ok := parseStatusCode(c); (ok && n >= 200 && n < 300) || strings.EqualFold(c, "2XX") {
+			codes = append(codes, c)
+		}
+	}
+	sort.Strings(codes)
+	if len(codes) == 0 {
+		if _, ok := responses["default"]; ok {
+			return ref + "[\"responses\"][\"default\"]"
+		}
+		return tsNever
+	}
+	parts := make([]string, 0, len(codes))
+	for _, c := range codes {
+		key := c
+		if _, ok := parseStatusCode(c); !ok {
+			key = strconv.Quote(c)
+		}
+		parts = append(parts, ref+"[\"responses\"]["+key+"]")
+	}
+	return strings.Join(parts, " | ")
 }
 
 func writeWebhooks(b *strings.Builder, ir *IR) {
 	if len(ir.Webhooks) == 0 {
 		return
 	}
-	writePathItems(b, "Webhooks", ir.Webhooks)
+	writePathItems(b, "Webhooks", "webhooks", ir.Webhooks, ir.order)
 }
 
-func writePathItems(b *strings.Builder, label string, items map[string]IRPathItem) {
+func writePathItems(b *strings.Builder, label, section string, items map[string]IRPathItem, order *keyOrder) {
 	b.WriteString("export type " + label + " = {\n")
+	writePathItemEntries(b, "  ", items, order, []string{section})
+	b.WriteString("};\n\n")
+}
 
+func writePathItemEntries(b *strings.Builder, indent string, items map[string]IRPathItem, order *keyOrder, at []string) {
 	keys := make([]string, 0, len(items))
 	for k := range items {
 		keys = append(keys, k)
 	}
-	sort.Strings(keys)
+	orderKeys(keys, order.keys(at...), nil)
 
 	for _, key := range keys {
 		item := items[key]
-		b.WriteString("  " + strconv.Quote(key) + ": {\n")
+		itemAt := append(at[:len(at):len(at)], key)
+		b.WriteString(indent + strconv.Quote(key) + ": {\n")
 
 		methods := make([]string, 0, len(item.Ops))
 		for m := range item.Ops {
 			methods = append(methods, m)
 		}
-		sort.Strings(methods)
+		orderKeys(methods, order.keys(itemAt...), nil)
 
+		in := indent + "  "
+		body := in + "  "
 		for _, method := range methods {
 			op := item.Ops[method]
-			b.WriteString("    " + method + ": {\n")
-
-			writeParamsBlock(b, "params", op.PathParams)
-			writeParamsBlock(b, "query", op.QueryParams)
-			writeParamsBlock(b, "headers", op.HeaderParams)
-			writeParamsBlock(b, "cookies", op.CookieParams)
+			opAt := append(itemAt[:len(itemAt):len(itemAt)], method)
+			b.WriteString(jsDoc(in, op.Doc))
+			b.WriteString(in + method + ": {\n")
+
+			params := order.params(itemAt, method)
+			writeParamsBlock(b, body, "params", op.PathParams, params)
+			writeParamsBlock(b, body, "query", op.QueryParams, params)
+			writeParamsBlock(b, body, "headers", op.HeaderParams, params)
+			writeParamsBlock(b, body, "cookies", op.CookieParams, params)
 			if len(op.Security) > 0 {
-				writeTSField(b, "      ", "security", securityRequirementsToTS(op.Security))
+				writeTSField(b, body, "security", RenderTS(securityRequirementsType(op.Security)))
 			}
 			if len(op.Servers) > 0 {
-				writeTSField(b, "      ", "servers", serversToTS(op.Servers))
+				writeTSField(b, body, "servers", RenderTS(serversType(op.Servers)))
 			}
 
-			if op.RequestBody != tsNever {
-				writeTSField(b, "      ", "requestBody", op.RequestBody)
+			if op.RequestBody != nil {
+				writeTSField(b, body, "requestBody", RenderTS(op.RequestBody))
 			}
-			b.WriteString("      responses: {\n")
+			b.WriteString(body + "responses: {\n")
 			codes := make([]string, 0, len(op.Responses))
 			for c := range op.Responses {
 				codes = append(codes, c)
 			}
-			sort.Slice(codes, func(i, j int) bool {
-				ai, aok := parseStatusCode(codes[i])
-				bi, bok := parseStatusCode(codes[j])
-				if aok && bok {
-					return ai < bi
-				}
-				if aok != bok {
-					return aok
-				}
-				return codes[i] < codes[j]
-			})
+			orderKeys(codes, order.keys(append(opAt, "responses")...), statusCodeLess)
 			for _, c := range codes {
 				key := c
 				if _, ok := parseStatusCode(c); !ok && c != "default" {
 					key = strconv.Quote(c)
 				}
-				writeTSField(b, "        ", key, op.Responses[c])
+				writeTSField(b, body+"  ", key, RenderTS(op.Responses[c]))
+			}
+			b.WriteString(body + "};\n")
+
+			if len(op.Callbacks) > 0 {
+				writeCallbacks(b, body, op.Callbacks, order, append(opAt, "callbacks"))
 			}
-			b.WriteString("      };\n")
 
-			b.WriteString("    };\n")
+			b.WriteString(in + "};\n")
 		}
 
-		b.WriteString("  };\n")
+		b.WriteString(indent + "};\n")
 	}
+}
 
-	b.WriteString("};\n\n")
+func writeCallbacks(b *strings.Builder, indent string, callbacks map[string]map[string]IRPathItem, order *keyOrder, at []string) {
+	b.WriteString(indent + "callbacks: {\n")
+	names := make([]string, 0, len(callbacks))
+	for name := range callbacks {
+		names = append(names, name)
+	}
+	orderKeys(names, order.keys(at...), nil)
+	for _, name := range names {
+		b.WriteString(indent + "  " + safeTSKey(name) + ": {\n")
+		writePathItemEntries(b, indent+"    ", callbacks[name], order, append(at[:len(at):len(at)], name))
+		b.WriteString(indent + "  };\n")
+	}
+	b.WriteString(indent + "};\n")
 }
 
 func writeServers(b *strings.Builder, ir *IR) {
 	if len(ir.Servers) == 0 {
 		return
 	}
-	b.WriteString("export type Servers = " + serversToTS(ir.Servers) + ";\n\n")
+	b.WriteString("export type Servers = " + RenderTS(serversType(ir.Servers)) + ";\n\n")
 }
 
-func serversToTS(servers []Server) string {
+func serversType(servers []Server) *TypeNode {
 	if len(servers) == 0 {
-		return "[]"
+		return tupleType()
 	}
-	parts := make([]string, 0, len(servers))
+	parts := make([]*TypeNode, 0, len(servers))
 	for _, s := range servers {
-		parts = append(parts, serverToTS(s))
+		parts = append(parts, serverType(s))
 	}
 	if len(parts) == 1 {
-		return parts[0] + "[]"
+		return arrayType(parts[0])
 	}
-	return "(" + strings.Join(parts, " | ") + ")[]"
+	return arrayType(unionOf(parts...))
 }
 
-func serverToTS(s Server) string {
-	fields := []fieldSpec{
-		{Name: "url", TS: strconv.Quote(s.URL), Optional: false},
+func serverType(s Server) *TypeNode {
+	fields := []Field{
+		{Name: "url", Type: literalType(s.URL)},
 	}
 	if s.Description != "" {
-		fields = append(fields, fieldSpec{Name: "description", TS: strconv.Quote(s.Description), Optional: false})
+		fields = append(fields, Field{Name: "description", Type: literalType(s.Description)})
 	}
 	if len(s.Variables) > 0 {
-		fields = append(fields, fieldSpec{Name: "variables", TS: serverVariablesToTS(s.Variables), Optional: false})
+		fields = append(fields, Field{Name: "variables", Type: serverVariablesType(s.Variables)})
 	}
-	return objectTypeFromFields(fields)
+	return sortedObjectType(fields)
 }
 
-func serverVariablesToTS(vars map[string]ServerVariable) string {
-	keys := make([]string, 0, len(vars))
-	for k := range vars {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-
-	fields := make([]fieldSpec, 0, len(keys))
-	for _, k := range keys {
-		v := vars[k]
-		valueFields := []fieldSpec{
-			{Name: "default", TS: strconv.Quote(v.Default), Optional: false},
+func serverVariablesType(vars map[string]ServerVariable) *TypeNode {
+	fields := make([]Field, 0, len(vars))
+	for k, v := range vars {
+		valueFields := []Field{
+			{Name: "default", Type: literalType(v.Default)},
 		}
 		if v.Description != "" {
-			valueFields = append(valueFields, fieldSpec{Name: "description", TS: strconv.Quote(v.Description), Optional: false})
+			valueFields = append(valueFields, Field{Name: "description", Type: literalType(v.Description)})
 		}
 		if len(v.Enum) > 0 {
-			enumVals := make([]string, 0, len(v.Enum))
+			enumVals := make([]*TypeNode, 0, len(v.Enum))
 			for _, ev := range v.Enum {
-				enumVals = append(enumVals, strconv.Quote(ev))
+				enumVals = append(enumVals, literalType(ev))
 			}
-			valueFields = append(valueFields, fieldSpec{Name: "enum", TS: literalUnion(enumVals) + "[]", Optional: false})
+			valueFields = append(valueFields, Field{Name: "enum", Type: arrayType(literalUnion(enumVals))})
 		}
-		fields = append(fields, fieldSpec{Name: k, TS: objectTypeFromFields(valueFields), Optional: false})
+		fields = append(fields, Field{Name: k, Type: sortedObjectType(valueFields)})
 	}
-	return objectTypeFromFields(fields)
+	return sortedObjectType(fields)
 }
 
-func securityRequirementsToTS(reqs []SecurityRequirement) string {
+func securityRequirementsType(reqs []SecurityRequirement) *TypeNode {
 	if len(reqs) == 0 {
-		return "[]"
+		return tupleType()
 	}
-	parts := make([]string, 0, len(reqs))
+	parts := make([]*TypeNode, 0, len(reqs))
 	for _, req := range reqs {
-		parts = append(parts, securityRequirementToTS(req))
+		parts = append(parts, securityRequirementType(req))
 	}
 	if len(parts) == 1 {
-		return parts[0] + "[]"
+		return arrayType(parts[0])
 	}
-	return "(" + strings.Join(parts, " | ") + ")[]"
+	return arrayType(unionOf(parts...))
 }
 
-func securityRequirementToTS(req SecurityRequirement) string {
-	if len(req) == 0 {
-		return tsEmptyObject
+func securityRequirementType(req SecurityRequirement) *TypeNode {
+	fields := make([]Field, 0, len(req))
+	for k, scopes := range req {
+		fields = append(fields, Field{Name: k, Type: scopesType(scopes)})
 	}
-	keys := make([]string, 0, len(req))
-	for k := range req {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-	fields := make([]fieldSpec, 0, len(keys))
-	for _, k := range keys {
-		scopes := req[k]
-		fields = append(fields, fieldSpec{Name: k, TS: scopesToTS(scopes), Optional: false})
-	}
-	return objectTypeFromFields(fields)
+	return sortedObjectType(fields)
 }
 
-func scopesToTS(scopes []string) string {
+func scopesType(scopes []string) *TypeNode {
 	if len(scopes) == 0 {
-		return "string[]"
+		return arrayType(stringType())
 	}
-	vals := make([]string, 0, len(scopes))
+	vals := make([]*TypeNode, 0, len(scopes))
 	for _, s := range scopes {
-		vals = append(vals, strconv.Quote(s))
+		vals = append(vals, literalType(s))
 	}
-	return literalUnion(vals) + "[]"
+	return arrayType(literalUnion(vals))
 }
 
 func writeTSField(b *strings.Builder, indent, key, value string) {
@@ -312,25 +488,37 @@ func safeTSKey(k string) string {
 	return strconv.Quote(k)
 }
 
-func writeParamsBlock(b *strings.Builder, label string, params map[string]paramResolved) {
+func writeParamsBlock(b *strings.Builder, indent, label string, params map[string]IRParam, declared []string) {
 	if len(params) == 0 {
 		return
 	}
-	b.WriteString("      " + label + ": {\n")
+	b.WriteString(indent + label + ": {\n")
 	keys := make([]string, 0, len(params))
 	for k := range params {
 		keys = append(keys, k)
 	}
-	sort.Strings(keys)
+	orderKeys(keys, declared, nil)
 	for _, k := range keys {
-		prop := safeTSKey(k)
-		if params[k].Required {
-			b.WriteString("        " + prop + ": " + params[k].TS + ";\n")
-		} else {
-			b.WriteString("        " + prop + "?: " + params[k].TS + ";\n")
+		key := safeTSKey(k)
+		if !params[k].Required {
+			key += "?"
 		}
+		b.WriteString(jsDoc(indent+"  ", params[k].Doc))
+		writeTSField(b, indent+"  ", key, RenderTS(params[k].Type))
 	}
-	b.WriteString("      };\n")
+	b.WriteString(indent + "};\n")
+}
+
+func statusCodeLess(a, b string) bool {
+	ai, aok := parseStatusCode(a)
+	bi, bok := parseStatusCode(b)
+	if aok && bok {
+		return ai < bi
+	}
+	if aok != bok {
+		return aok
+	}
+	return a < b
 }
 
 func parseStatusCode(s string) (int, bool) {
@@ -341,6 +529,23 @@ func parseStatusCode(s string) (int, bool) {
 	return n, true
 }
 
+var tsReservedTypeNames = map[string]bool{
+	"any": true, "bigint": true, "boolean": true, "never": true, "null": true, "number": true,
+	"object": true, "string": true, "symbol": true, "undefined": true, "unknown": true, "void": true,
+	"break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+	"debugger": true, "default": true, "delete": true, "do": true, "else": true, "enum": true,
+	"export": true, "extends": true, "false": true, "finally": true, "for": true, "function": true,
+	"if": true, "implements": true, "import": true, "in": true, "instanceof": true, "interface": true,
+	"let": true, "new": true, "package": true, "private": true, "protected": true, "public": true,
+	"return": true, "static": true, "super": true, "switch": true, "this": true, "throw": true,
+	"true": true, "try": true, "type": true, "typeof": true, "var": true, "while": true,
+	"with": true, "yield": true,
+	"Array": true, "Blob": true, "Boolean": true, "Date": true, "Error": true, "Exclude": true,
+	"Extract": true, "File": true, "Map": true, "NonNullable": true, "Number": true, "Object": true,
+	"Omit": true, "Partial": true, "Pick": true, "Promise": true, "Readonly": true, "Record": true,
+	"Required": true, "Set": true, "String": true,
+}
+
 func isTSIdent(s string) bool {
 	if s == "" {
 		return false
@@ -0,0 +1,134 @@
+package schema
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"time"
+)
+
+const defaultTypesImport = "./types"
+
+type Options struct {
+	Now                  func() time.Time
+	Formats              map[string]string
+	Version              string
+	TypesImport          string
+	BaseDir              string
+	Format               InputFormat
+	ComponentAliasPrefix string
+	ComponentAliasSuffix string
+	EnumStyle            EnumStyle
+	Split                SplitMode
+	Client               bool
+	Zod                  bool
+	ComponentAliases     bool
+	OperationAliases     bool
+	Brands               bool
+	PreserveOrder        bool
+	FixedTuples          bool
+}
+
+type Result struct {
+	Files       map[string]string
+	Types       string
+	Client      string
+	Zod         string
+	OpenAPI     string
+	Diagnostics []Diagnostic
+}
+
+type Generator struct {
+	opts Options
+}
+
+func NewGenerator(opts Options) *Generator {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.Format == "" {
+		opts.Format = InputAuto
+	}
+	if opts.TypesImport == "" {
+		opts.TypesImport = defaultTypesImport
+	}
+	return &Generator{opts: opts}
+}
+
+func (g *Generator) Generate(r io.Reader) (*Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read schema: %w", err)
+	}
+	doc, _, err := loadDocumentData(data, g.opts.BaseDir, g.opts.Format)
+	if err != nil {
+		return nil, err
+	}
+	return g.generate(doc)
+}
+
+func (g *Generator) GenerateFS(fsys fs.FS, name string) (*Result, error) {
+	doc, _, err := loadDocumentFS(fsys, name, g.opts.Format)
+	if err != nil {
+		return nil, err
+	}
+	return g.generate(doc)
+}
+
+func (g *Generator) generateFile(path string) (*Result, error) {
+	doc, err := LoadDocument(path, g.opts.Format)
+	if err != nil {
+		return nil, err
+	}
+	return g.generate(doc)
+}
+
+func (g *Generator) generate(doc *Document) (*Result, error) {
+	enumStyle, err := ParseEnumStyle(string(g.opts.EnumStyle))
+	if err != nil {
+		return nil, err
+	}
+	split, err := ParseSplitMode(string(g.opts.Split))
+	if err != nil {
+		return nil, err
+	}
+	irOpts := IROptions{
+		Formats:       g.opts.Formats,
+		Brands:        g.opts.Brands,
+		PreserveOrder: g.opts.PreserveOrder,
+		FixedTuples:   g.opts.FixedTuples,
+	}
+	ir, err := ToIRWith(doc, irOpts)
+	if err != nil {
+		return nil, fmt.Errorf("build IR: %w", err)
+	}
+
+	now := g.opts.Now()
+	emitOpts := EmitOptions{
+		ComponentAliasPrefix: g.opts.ComponentAliasPrefix,
+		ComponentAliasSuffix: g.opts.ComponentAliasSuffix,
+		EnumStyle:            enumStyle,
+		ComponentAliases:     g.opts.ComponentAliases,
+		OperationAliases:     g.opts.OperationAliases,
+	}
+	res := &Result{
+		OpenAPI:     doc.OpenAPI,
+		Diagnostics: ir.Diagnostics,
+	}
+	if split == SplitNone {
+		res.Types = normalizeGeneratedOutput(EmitTypesFromIRWith(ir, now, g.opts.Version, doc.OpenAPI, emitOpts))
+	} else {
+		res.Files = EmitTypeFilesFromIR(ir, now, g.opts.Version, doc.OpenAPI, emitOpts, split)
+	}
+	if g.opts.Client {
+		res.Client = normalizeGeneratedOutput(EmitClientFromIRAt(ir, now, g.opts.Version, doc.OpenAPI, g.opts.TypesImport))
+	}
+	if g.opts.Zod {
+		out, err := EmitZodFromDocumentWith(doc, now, g.opts.Version, doc.OpenAPI, irOpts)
+		if err != nil {
+			return nil, fmt.Errorf("build zod schemas: %w", err)
+		}
+		res.Zod = normalizeGeneratedOutput(out)
+	}
+	return res, nil
+}
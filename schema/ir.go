@@ -23,19 +23,27 @@ var (
 	ErrNestedHeaderRef                = errors.New("nested header $ref")
 	ErrMissingComponentSecurityScheme = errors.New("missing components.securitySchemes")
 	ErrNestedSecuritySchemeRef        = errors.New("nested securityScheme $ref")
+	ErrMissingComponentCallback       = errors.New("missing components.callbacks")
+	ErrNestedCallbackRef              = errors.New("nested callback $ref")
+	ErrCallbackCycle                  = errors.New("callback $ref cycle")
 )
 
 type IR struct {
 	Paths                     map[string]IRPathItem
 	Webhooks                  map[string]IRPathItem
-	ComponentsSchemas         map[string]string
-	ComponentsResponses       map[string]string
-	ComponentsRequestBody     map[string]string
-	ComponentsParameters      map[string]string
-	ComponentsHeaders         map[string]string
-	ComponentsSecuritySchemes map[string]string
-	Enums                     map[string]string
+	ComponentsSchemas         map[string]*TypeNode
+	ComponentsResponses       map[string]*TypeNode
+	ComponentsRequestBody     map[string]*TypeNode
+	ComponentsParameters      map[string]*TypeNode
+	ComponentsHeaders         map[string]*TypeNode
+	ComponentsSecuritySchemes map[string]*TypeNode
+	ComponentsDocs            map[string]map[string][]string
+	Enums                     map[string]IREnum
+	Brands                    map[string]IRBrand
+	order                     *keyOrder
 	Servers                   []Server
+	Operations                []IROperationName
+	Diagnostics               []Diagnostic
 }
 
 type schemaMode int
@@ -45,17 +53,37 @@ type IRPathItem struct {
 }
 
 type IROperation struct {
-	PathParams   map[string]paramResolved
-	QueryParams  map[string]paramResolved
-	HeaderParams map[string]paramResolved
-	CookieParams map[string]paramResolved
-	Responses    map[string]string
-	RequestBody  string
+	PathParams   map[string]IRParam
+	QueryParams  map[string]IRParam
+	HeaderParams map[string]IRParam
+	CookieParams map[string]IRParam
+	Responses    map[string]*TypeNode
+	Callbacks    map[string]map[string]IRPathItem
+	RequestBody  *TypeNode
 	Security     []SecurityRequirement
 	Servers      []Server
+	Doc          []string
+	Tags         []string
+	OperationID  string
+	BodyRequired bool
+}
+
+type IROptions struct {
+	Formats       map[string]string
+	Brands        bool
+	PreserveOrder bool
+	FixedTuples   bool
+}
+
+var DefaultFormats = map[string]string{
+	"binary": "Blob",
 }
 
 func ToIR(doc *Document) (*IR, error) {
+	return ToIRWith(doc, IROptions{})
+}
+
+func ToIRWith(doc *Document, opts IROptions) (*IR, error) {
 	if doc == nil {
 		return nil, ErrNilDoc
 	}
@@ -63,17 +91,22 @@ func ToIR(doc *Document) (*IR, error) {
 	out := &IR{
 		Paths:                     map[string]IRPathItem{},
 		Webhooks:                  map[string]IRPathItem{},
-		ComponentsSchemas:         map[string]string{},
-		ComponentsResponses:       map[string]string{},
-		ComponentsRequestBody:     map[string]string{},
-		ComponentsParameters:      map[string]string{},
-		ComponentsHeaders:         map[string]string{},
-		ComponentsSecuritySchemes: map[string]string{},
-		Enums:                     map[string]string{},
+		ComponentsSchemas:         map[string]*TypeNode{},
+		ComponentsResponses:       map[string]*TypeNode{},
+		ComponentsRequestBody:     map[string]*TypeNode{},
+		ComponentsParameters:      map[string]*TypeNode{},
+		ComponentsHeaders:         map[string]*TypeNode{},
+		ComponentsSecuritySchemes: map[string]*TypeNode{},
+		ComponentsDocs:            map[string]map[string][]string{},
+		Enums:                     map[string]IREnum{},
+		Brands:                    map[string]IRBrand{},
 		Servers:                   doc.Servers,
 	}
 
 	ctx := newEnumContext(out.Enums)
+	ctx.diags = newDiagnostics()
+	ctx.configure(doc, opts, out.Brands)
+	out.order = ctx.order
 	if err := populateComponents(out, doc, ctx); err != nil {
 		return nil, err
 	}
@@ -83,6 +116,8 @@ func ToIR(doc *Document) (*IR, error) {
 	if err := populateWebhooks(out, doc, ctx); err != nil {
 		return nil, err
 	}
+	out.Operations = assignOperationNames(out.Paths)
+	out.Diagnostics = ctx.diags.items
 	return out, nil
 }
 
@@ -122,7 +157,10 @@ func populateComponentSchemas(out *IR, doc *Document, ctx *enumContext) error {
 	sort.Strings(keys)
 	for _, k := range keys {
 		sch := doc.Components.Schemas[k]
-		out.ComponentsSchemas[k] = schemaToTS(doc, &RefOr[Schema]{Value: &sch}, 0, ctx, k, modeDefault)
+		leave := ctx.enterAt("components", "schemas", k)
+		out.ComponentsSchemas[k] = componentSchemaToType(doc, k, &sch, 0, ctx, modeDefault)
+		leave()
+		out.setComponentDoc("schemas", k, componentSchemaDoc(&sch))
 	}
 	return nil
 }
@@ -142,7 +180,12 @@ func populateComponentResponses(out *IR, doc *Document, ctx *enumContext) error
 		if err != nil {
 			return fmt.Errorf("components.responses.%s: %w", k, err)
 		}
-		out.ComponentsResponses[k] = responseToTS(doc, resp, ctx, k, modeOutput)
+		leave := ctx.enterAt("components", "responses", k)
+		out.ComponentsResponses[k] = responseToType(doc, resp, ctx, k, modeOutput)
+		leave()
+		if resp != nil {
+			out.setComponentDoc("responses", k, appendDocText(nil, "@description", resp.Description))
+		}
 	}
 	return nil
 }
@@ -161,7 +204,12 @@ func populateComponentRequestBodies(out *IR, doc *Document, ctx *enumContext) er
 		if err != nil {
 			return fmt.Errorf("components.requestBodies.%s: %w", k, err)
 		}
-		out.ComponentsRequestBody[k] = requestBodyToTS(doc, rb, ctx, k, modeInput)
+		leave := ctx.enterAt("components", "requestBodies", k)
+		out.ComponentsRequestBody[k] = requestBodyToType(doc, rb, ctx, k, modeInput)
+		leave()
+		if rb != nil {
+			out.setComponentDoc("requestBodies", k, appendDocText(nil, "@description", rb.Description))
+		}
 	}
 	return nil
 }
@@ -180,7 +228,10 @@ func populateComponentParameters(out *IR, doc *Document, ctx *enumContext) error
 		if err != nil {
 			return fmt.Errorf("components.parameters.%s: %w", k, err)
 		}
-		out.ComponentsParameters[k] = parameterToTS(doc, p, ctx, k, modeInput)
+		leave := ctx.enterAt("components", "parameters", k)
+		out.ComponentsParameters[k] = parameterToType(doc, p, ctx, k, modeInput)
+		leave()
+		out.setComponentDoc("parameters", k, parameterDoc(p))
 	}
 	return nil
 }
@@ -199,7 +250,10 @@ func populateComponentHeaders(out *IR, doc *Document, ctx *enumContext) error {
 		if err != nil {
 			return fmt.Errorf("components.headers.%s: %w", k, err)
 		}
-		out.ComponentsHeaders[k] = headerToTS(doc, h, ctx, k, modeOutput)
+		leave := ctx.enterAt("components", "headers", k)
+		out.ComponentsHeaders[k] = headerToType(doc, h, ctx, k, modeOutput)
+		leave()
+		out.setComponentDoc("headers", k, headerDoc(h))
 	}
 	return nil
 }
@@ -218,11 +272,23 @@ func populateComponentSecuritySchemes(out *IR, doc *Document) error {
 		if err != nil {
 			return fmt.Errorf("components.securitySchemes.%s: %w", k, err)
 		}
-		out.ComponentsSecuritySchemes[k] = securitySchemeToTS(ss)
+		out.ComponentsSecuritySchemes[k] = securitySchemeToType(ss)
 	}
 	return nil
 }
 
+func (ir *IR) setComponentDoc(section, name string, doc []string) {
+	if len(doc) == 0 {
+		return
+	}
+	docs, ok := ir.ComponentsDocs[section]
+	if !ok {
+		docs = map[string][]string{}
+		ir.ComponentsDocs[section] = docs
+	}
+	docs[name] = doc
+}
+
 func populatePaths(out *IR, doc *Document, ctx *enumContext) error {
 	pathKeys := make([]string, 0, len(doc.Paths))
 	for k := range doc.Paths {
@@ -239,7 +305,9 @@ func populatePaths(out *IR, doc *Document, ctx *enumContext) error {
 			continue
 		}
 
+		leave := ctx.enterAt("paths", path)
 		ops, err := pathItemToOps(doc, pi, ctx)
+		leave()
 		if err != nil {
 			return fmt.Errorf("path %q: %w", path, err)
 		}
@@ -274,7 +342,9 @@ func populateWebhooks(out *IR, doc *Document, ctx *enumContext) error {
 			continue
 		}
 
+		leave := ctx.enterAt("webhooks", name)
 		ops, err := pathItemToOps(doc, pi, ctx)
+		leave()
 		if err != nil {
 			return fmt.Errorf("webhook %q: %w", name, err)
 		}
@@ -311,6 +381,7 @@ func pathItemToOps(doc *Document, pi *PathItem, ctx *enumContext) (map[string]IR
 		if op == nil {
 			return nil
 		}
+		defer ctx.enter(method)()
 
 		opParams, err := collectParams(doc, op.Parameters, ctx)
 		if err != nil {
@@ -323,16 +394,21 @@ func pathItemToOps(doc *Document, pi *PathItem, ctx *enumContext) (map[string]IR
 		headerOnly := filterParamsByIn(mergedParams, "header")
 		cookieOnly := filterParamsByIn(mergedParams, "cookie")
 
-		reqTS, err := opRequestBodyTS(doc, op, ctx, method)
+		reqTS, err := opRequestBodyType(doc, op, ctx, method)
 		if err != nil {
 			return fmt.Errorf("%s requestBody: %w", method, err)
 		}
 
-		respTS, err := opResponsesTS(doc, op, ctx, method)
+		respTS, err := opResponseTypes(doc, op, ctx, method)
 		if err != nil {
 			return fmt.Errorf("%s responses: %w", method, err)
 		}
 
+		callbacks, err := opCallbacks(doc, op, ctx)
+		if err != nil {
+			return fmt.Errorf("%s callbacks: %w", method, err)
+		}
+
 		security := op.Security
 		if len(security) == 0 {
 			security = doc.Security
@@ -353,8 +429,13 @@ func pathItemToOps(doc *Document, pi *PathItem, ctx *enumContext) (map[string]IR
 			CookieParams: cookieOnly,
 			RequestBody:  reqTS,
 			Responses:    respTS,
+			Callbacks:    callbacks,
 			Security:     security,
 			Servers:      servers,
+			Doc:          operationDoc(op),
+			Tags:         op.Tags,
+			OperationID:  op.OperationID,
+			BodyRequired: opRequestBodyRequired(doc, op),
 		}
 		return nil
 	}
@@ -373,19 +454,22 @@ type paramKey struct {
 	In   string
 }
 
-type paramResolved struct {
+type IRParam struct {
+	Explode  *bool
+	Type     *TypeNode
 	In       string
-	TS       string
+	Style    string
+	Doc      []string
 	Required bool
 }
 
-func collectParams(doc *Document, params []RefOr[Parameter], ctx *enumContext) (map[paramKey]paramResolved, error) {
-	out := map[paramKey]paramResolved{}
+func collectParams(doc *Document, params []RefOr[Parameter], ctx *enumContext) (map[paramKey]IRParam, error) {
+	out := map[paramKey]IRParam{}
 	for i := range params {
-		refTS := ""
+		var t *TypeNode
 		if params[i].Ref != "" {
 			if name, ok := refComponentName(params[i].Ref, "parameters"); ok {
-				refTS = componentParameterRef(name)
+				t = refType("parameters", name)
 			}
 		}
 		p, err := resolveParameter(doc, params[i])
@@ -395,21 +479,29 @@ func collectParams(doc *Document, params []RefOr[Parameter], ctx *enumContext) (
 		if p == nil {
 			continue
 		}
-		ts := refTS
-		if ts == "" {
-			ts = parameterToTS(doc, p, ctx, p.Name, modeInput)
+		if t == nil {
+			leave := ctx.enter("parameters", strconv.Itoa(i))
+			t = parameterToType(doc, p, ctx, p.Name, modeInput)
+			leave()
 		}
 		required := p.Required
 		if p.In == "path" {
 			required = true
 		}
-		out[paramKey{Name: p.Name, In: p.In}] = paramResolved{In: p.In, TS: ts, Required: required}
+		out[paramKey{Name: p.Name, In: p.In}] = IRParam{
+			In:       p.In,
+			Type:     t,
+			Style:    p.Style,
+			Explode:  p.Explode,
+			Doc:      parameterDoc(p),
+			Required: required,
+		}
 	}
 	return out, nil
 }
 
-func mergeParamMaps(a, b map[paramKey]paramResolved) map[paramKey]paramResolved {
-	out := map[paramKey]paramResolved{}
+func mergeParamMaps(a, b map[paramKey]IRParam) map[paramKey]IRParam {
+	out := map[paramKey]IRParam{}
 	for k, v := range a {
 		out[k] = v
 	}
@@ -419,8 +511,8 @@ func mergeParamMaps(a, b map[paramKey]paramResolved) map[paramKey]paramResolved
 	return out
 }
 
-func filterParamsByIn(m map[paramKey]paramResolved, in string) map[string]paramResolved {
-	out := map[string]paramResolved{}
+func filterParamsByIn(m map[paramKey]IRParam, in string) map[string]IRParam {
+	out := map[string]IRParam{}
 	for k, v := range m {
 		if k.In == in {
 			out[k.Name] = v
@@ -429,24 +521,33 @@ func filterParamsByIn(m map[paramKey]paramResolved, in string) map[string]paramR
 	return out
 }
 
-func opRequestBodyTS(doc *Document, op *Operation, ctx *enumContext, opName string) (string, error) {
+func opRequestBodyType(doc *Document, op *Operation, ctx *enumContext, opName string) (*TypeNode, error) {
 	if op.RequestBody == nil {
-		return tsNever, nil
+		return nil, nil
 	}
 	if op.RequestBody.Ref != "" {
 		if name, ok := refComponentName(op.RequestBody.Ref, "requestBodies"); ok {
-			return componentRequestBodyRef(name), nil
+			return refType("requestBodies", name), nil
 		}
 	}
 	rb, err := resolveRequestBody(doc, *op.RequestBody)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return requestBodyToTS(doc, rb, ctx, joinEnumHint(opName, "RequestBody"), modeInput), nil
+	defer ctx.enter("requestBody")()
+	return requestBodyToType(doc, rb, ctx, joinEnumHint(opName, "RequestBody"), modeInput), nil
 }
 
-func opResponsesTS(doc *Document, op *Operation, ctx *enumContext, opName string) (map[string]string, error) {
-	out := map[string]string{}
+func opRequestBodyRequired(doc *Document, op *Operation) bool {
+	if op.RequestBody == nil {
+		return false
+	}
+	rb, err := resolveRequestBody(doc, *op.RequestBody)
+	return err == nil && rb != nil && rb.Required
+}
+
+func opResponseTypes(doc *Document, op *Operation, ctx *enumContext, opName string) (map[string]*TypeNode, error) {
+	out := map[string]*TypeNode{}
 
 	if len(op.Responses) == 0 {
 		return out, nil
@@ -474,7 +575,7 @@ func opResponsesTS(doc *Document, op *Operation, ctx *enumContext, opName string
 		r := op.Responses[code]
 		if r.Ref != "" {
 			if name, ok := refComponentName(r.Ref, "responses"); ok {
-				out[code] = componentResponseRef(name)
+				out[code] = refType("responses", name)
 				continue
 			}
 		}
@@ -482,7 +583,9 @@ func opResponsesTS(doc *Document, op *Operation, ctx *enumContext, opName string
 		if err != nil {
 			return nil, err
 		}
-		out[code] = responseToTS(doc, resp, ctx, joinEnumHint(opName, "Response_"+code), modeOutput)
+		leave := ctx.enter("responses", code)
+		out[code] = responseToType(doc, resp, ctx, joinEnumHint(opName, "Response_"+code), modeOutput)
+		leave()
 	}
 
 	return out, nil
@@ -496,7 +599,7 @@ func parseStatus(s string) (int, bool) {
 	return i, true
 }
 
-func contentToTS(doc *Document, content map[string]MediaType, empty string, ctx *enumContext, nameHint string, mode schemaMode) string {
+func contentToType(doc *Document, content map[string]MediaType, empty *TypeNode, ctx *enumContext, nameHint string, mode schemaMode) *TypeNode {
 	if len(content) == 0 {
 		return empty
 	}
@@ -507,40 +610,42 @@ func contentToTS(doc *Document, content map[string]MediaType, empty string, ctx
 	sort.Strings(keys)
 
 	seen := map[string]bool{}
-	parts := make([]string, 0, len(keys))
+	parts := make([]*TypeNode, 0, len(keys))
 	for _, k := range keys {
 		mt := content[k]
-		ts := tsUnknown
+		t := unknownType()
 		if mt.Schema != nil {
-			ts = schemaToTS(doc, mt.Schema, 0, ctx, joinEnumHint(nameHint, mediaTypeSuffix(k)), mode)
+			leave := ctx.enter("content", k, "schema")
+			t = schemaToType(doc, mt.Schema, 0, ctx, joinEnumHint(nameHint, mediaTypeSuffix(k)), mode)
+			leave()
 		}
-		if !seen[ts] {
-			seen[ts] = true
-			parts = append(parts, ts)
+		if key := typeKey(t); !seen[key] {
+			seen[key] = true
+			parts = append(parts, t)
 		}
 	}
 	if len(parts) == 1 {
 		return parts[0]
 	}
-	return "(" + strings.Join(parts, " | ") + ")"
+	return unionOf(parts...)
 }
 
-func requestBodyToTS(doc *Document, rb *RequestBody, ctx *enumContext, nameHint string, mode schemaMode) string {
+func requestBodyToType(doc *Document, rb *RequestBody, ctx *enumContext, nameHint string, mode schemaMode) *TypeNode {
 	if rb == nil {
-		return tsUnknown
+		return unknownType()
 	}
-	return contentToTS(doc, rb.Content, tsUnknown, ctx, nameHint, mode)
+	return contentToType(doc, rb.Content, unknownType(), ctx, nameHint, mode)
 }
 
-func responseToTS(doc *Document, resp *Response, ctx *enumContext, nameHint string, mode schemaMode) string {
+func responseToType(doc *Document, resp *Response, ctx *enumContext, nameHint string, mode schemaMode) *TypeNode {
 	if resp == nil {
-		return tsNever
+		return neverType()
 	}
 
-	bodyTS := contentToTS(doc, resp.Content, tsNever, ctx, nameHint, mode)
+	body := contentToType(doc, resp.Content, neverType(), ctx, nameHint, mode)
 
 	if len(resp.Headers) == 0 {
-		return bodyTS
+		return body
 	}
 
 	keys := make([]string, 0, len(resp.Headers))
@@ -549,56 +654,59 @@ func responseToTS(doc *Document, resp *Response, ctx *enumContext, nameHint stri
 	}
 	sort.Strings(keys)
 
-	var b strings.Builder
-	b.WriteString("{\n")
-	b.WriteString("  headers: {\n")
+	headers := make([]Field, 0, len(keys))
 	for _, k := range keys {
 		h := resp.Headers[k]
-		refTS := ""
+		var t *TypeNode
 		if h.Ref != "" {
 			if name, ok := refComponentName(h.Ref, "headers"); ok {
-				refTS = componentHeaderRef(name)
+				t = refType("headers", name)
 			}
 		}
+		leave := ctx.enter("headers", k)
 		hv, err := resolveHeader(doc, h)
 		if err != nil {
-			refTS = tsUnknown
+			ctx.warn("unresolvable response header, emitted unknown: %v", err)
+			t = unknownType()
 			hv = nil
 		}
-		fieldTS := refTS
-		if fieldTS == "" {
-			fieldTS = headerToTS(doc, hv, ctx, k, modeOutput)
-		}
-		if hv != nil && hv.Required {
-			b.WriteString("    " + safeProp(k) + ": " + fieldTS + ";\n")
-		} else {
-			b.WriteString("    " + safeProp(k) + "?: " + fieldTS + ";\n")
+		if t == nil {
+			t = headerToType(doc, hv, ctx, k, modeOutput)
 		}
+		leave()
+		headers = append(headers, Field{
+			Name:     k,
+			Type:     t,
+			Doc:      headerDoc(hv),
+			Optional: hv == nil || !hv.Required,
+		})
 	}
-	b.WriteString("  };\n")
-	b.WriteString("  body: " + bodyTS + ";\n")
-	b.WriteString("}")
-	return b.String()
+	return objectType([]Field{
+		{Name: "headers", Type: objectType(headers)},
+		{Name: "body", Type: body},
+	})
 }
 
-func parameterToTS(doc *Document, p *Parameter, ctx *enumContext, nameHint string, mode schemaMode) string {
+func parameterToType(doc *Document, p *Parameter, ctx *enumContext, nameHint string, mode schemaMode) *TypeNode {
 	if p == nil {
-		return tsUnknown
+		return unknownType()
 	}
 	if p.Schema != nil {
-		return schemaToTS(doc, p.Schema, 0, ctx, nameHint, mode)
+		defer ctx.enter("schema")()
+		return schemaToType(doc, p.Schema, 0, ctx, nameHint, mode)
 	}
-	return contentToTS(doc, p.Content, tsUnknown, ctx, nameHint, mode)
+	return contentToType(doc, p.Content, unknownType(), ctx, nameHint, mode)
 }
 
-func headerToTS(doc *Document, h *Header, ctx *enumContext, nameHint string, mode schemaMode) string {
+func headerToType(doc *Document, h *Header, ctx *enumContext, nameHint string, mode schemaMode) *TypeNode {
 	if h == nil {
-		return tsUnknown
+		return unknownType()
 	}
 	if h.Schema != nil {
-		return schemaToTS(doc, h.Schema, 0, ctx, nameHint, mode)
+		defer ctx.enter("schema")()
+		return schemaToType(doc, h.Schema, 0, ctx, nameHint, mode)
 	}
-	return contentToTS(doc, h.Content, tsUnknown, ctx, nameHint, mode)
+	return contentToType(doc, h.Content, unknownType(), ctx, nameHint, mode)
 }
 
 func resolvePathItem(doc *Document, v RefOr[PathItem]) (*PathItem, error) {
@@ -619,6 +727,105 @@ func resolvePathItem(doc *Document, v RefOr[PathItem]) (*PathItem, error) {
 	return pi.Value, nil
 }
 
+func opCallbacks(doc *Document, op *Operation, ctx *enumContext) (map[string]map[string]IRPathItem, error) {
+	if len(op.Callbacks) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(op.Callbacks))
+	for k := range op.Callbacks {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	out := map[string]map[string]IRPathItem{}
+	for _, name := range names {
+		v := op.Callbacks[name]
+		cb, err := resolveCallback(doc, v)
+		if err != nil {
+			return nil, fmt.Errorf("callback %q: %w", name, err)
+		}
+		if cb == nil {
+			continue
+		}
+
+		var leave func()
+		if component, ok := refComponentName(v.Ref, "callbacks"); ok {
+			if ctx.callbacks[component] {
+				return nil, fmt.Errorf("callback %q: %w: %q", name, ErrCallbackCycle, v.Ref)
+			}
+			ctx.callbacks[component] = true
+			done := ctx.enterAt("components", "callbacks", component)
+			leave = func() {
+				done()
+				delete(ctx.callbacks, component)
+			}
+		} else {
+			leave = ctx.enter("callbacks", name)
+		}
+		items, err := callbackPathItems(doc, *cb, ctx)
+		leave()
+		if err != nil {
+			return nil, fmt.Errorf("callback %q: %w", name, err)
+		}
+		if len(items) > 0 {
+			out[name] = items
+		}
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
+
+func callbackPathItems(doc *Document, cb Callback, ctx *enumContext) (map[string]IRPathItem, error) {
+	exprs := make([]string, 0, len(cb))
+	for k := range cb {
+		exprs = append(exprs, k)
+	}
+	sort.Strings(exprs)
+
+	items := map[string]IRPathItem{}
+	for _, expr := range exprs {
+		item := cb[expr]
+		pi, err := resolvePathItem(doc, RefOr[PathItem]{Value: &item, Ref: item.Ref})
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", expr, err)
+		}
+		if pi == nil {
+			continue
+		}
+
+		leave := ctx.enter(expr)
+		ops, err := pathItemToOps(doc, pi, ctx)
+		leave()
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", expr, err)
+		}
+		if len(ops) > 0 {
+			items[expr] = IRPathItem{Ops: ops}
+		}
+	}
+	return items, nil
+}
+
+func resolveCallback(doc *Document, v RefOr[Callback]) (*Callback, error) {
+	if v.Ref == "" {
+		return v.Value, nil
+	}
+	name, ok := refComponentName(v.Ref, "callbacks")
+	if !ok || doc.Components == nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedRef, v.Ref)
+	}
+	cb, ok := doc.Components.Callbacks[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingComponentCallback, name)
+	}
+	if cb.Ref != "" {
+		return nil, fmt.Errorf("%w: %q", ErrNestedCallbackRef, cb.Ref)
+	}
+	return cb.Value, nil
+}
+
 func resolveRequestBody(doc *Document, v RefOr[RequestBody]) (*RequestBody, error) {
 	if v.Ref == "" {
 		return v.Value, nil
@@ -717,137 +924,257 @@ func refComponentName(ref, section string) (string, bool) {
 	return "", false
 }
 
-func schemaToTS(doc *Document, s *RefOr[Schema], depth int, ctx *enumContext, nameHint string, mode schemaMode) string {
+func schemaToType(doc *Document, s *RefOr[Schema], depth int, ctx *enumContext, nameHint string, mode schemaMode) *TypeNode {
 	if s == nil {
-		return tsUnknown
+		return unknownType()
 	}
 	if s.Ref != "" {
-		return schemaRefToTS(doc, s.Ref, depth, ctx, mode)
+		return schemaRefToType(doc, s.Ref, depth, ctx, mode)
 	}
 	if s.Value == nil {
-		return tsUnknown
+		return unknownType()
 	}
-	if depth > 30 {
-		return tsUnknown
+	if depth > maxSchemaDepth {
+		ctx.warnDepth()
+		return unknownType()
 	}
 
-	return schemaValueToTS(doc, s.Value.Other, depth, ctx, nameHint, mode)
+	return schemaValueToType(doc, schemaMap(s.Value), depth, ctx, nameHint, mode)
 }
 
-func schemaRefToTS(doc *Document, ref string, depth int, ctx *enumContext, mode schemaMode) string {
+func schemaRefToType(doc *Document, ref string, depth int, ctx *enumContext, mode schemaMode) *TypeNode {
 	if name, ok := refComponentName(ref, "schemas"); ok {
-		if mode == modeDefault {
-			return componentSchemaRef(name)
+		if mode == modeDefault || !ctx.inlinesRef(doc, name) {
+			return refType("schemas", name)
 		}
 		if doc != nil && doc.Components != nil {
 			if sch, ok := doc.Components.Schemas[name]; ok {
-				return schemaToTS(doc, &RefOr[Schema]{Value: &sch}, depth+1, ctx, name, mode)
+				leave, ok := ctx.inline(name)
+				if !ok {
+					return refType("schemas", name)
+				}
+				defer leave()
+				defer ctx.enterComponent("schemas", name)()
+				return componentSchemaToType(doc, name, &sch, depth+1, ctx, mode)
 			}
 		}
-		return componentSchemaRef(name)
+		return refType("schemas", name)
+	}
+	ctx.warn("unsupported $ref %q, emitted unknown", ref)
+	return unknownType()
+}
+
+func componentSchemaToType(doc *Document, name string, sch *Schema, depth int, ctx *enumContext, mode schemaMode) *TypeNode {
+	if t, ok := ctx.brandType(schemaMap(sch), name); ok {
+		return t
 	}
-	return tsUnknown
+	return schemaToType(doc, &RefOr[Schema]{Value: sch}, depth, ctx, name, mode)
 }
 
-func schemaValueToTS(doc *Document, o map[string]any, depth int, ctx *enumContext, nameHint string, mode schemaMode) string {
-	if ts, ok := schemaEnumOrConstToTS(o, ctx, nameHint); ok {
-		return ts
+func schemaValueToType(doc *Document, o map[string]any, depth int, ctx *enumContext, nameHint string, mode schemaMode) *TypeNode {
+	if t, ok := schemaEnumOrConstToType(o, ctx, nameHint); ok {
+		return t
 	}
-	if ts, ok := schemaCombinatorToTS(doc, o, depth, ctx, nameHint, mode); ok {
-		return ts
+	if t, ok := schemaCombinatorToType(doc, o, depth, ctx, nameHint, mode); ok {
+		return t
 	}
-	return schemaTypeToTS(doc, o, depth, ctx, nameHint, mode)
+	return schemaTypeToType(doc, o, depth, ctx, nameHint, mode)
 }
 
-func schemaEnumOrConstToTS(o map[string]any, ctx *enumContext, nameHint string) (string, bool) {
+func schemaEnumOrConstToType(o map[string]any, ctx *enumContext, nameHint string) (*TypeNode, bool) {
 	if cv, ok := o["const"]; ok {
 		if ctx != nil {
-			if ts := ctx.emitEnum(nameHint, []any{cv}, o); ts != "" {
-				return ts, true
+			if t := ctx.emitEnum(nameHint, []any{cv}, o); t != nil {
+				return t, true
 			}
 		}
-		if ts := literalToTS(cv); ts != "" {
-			return applyNullable(ts, o), true
+		if t, ok := literalValue(cv); ok {
+			return applyNullable(t, o), true
 		}
 	}
 	if ev := anySlice(o["enum"]); len(ev) > 0 {
 		if ctx != nil {
-			if ts := ctx.emitEnum(nameHint, ev, o); ts != "" {
-				return ts, true
+			if t := ctx.emitEnum(nameHint, ev, o); t != nil {
+				return t, true
 			}
 		}
-		parts := make([]string, 0, len(ev))
+		parts := make([]*TypeNode, 0, len(ev))
 		for _, it := range ev {
-			if ts := literalToTS(it); ts != "" {
-				parts = append(parts, ts)
-			} else {
-				return applyNullable(tsUnknown, o), true
+			t, ok := literalValue(it)
+			if !ok {
+				ctx.warn("enum value %v cannot be expressed as a literal, emitted unknown", it)
+				return applyNullable(unknownType(), o), true
 			}
+			parts = append(parts, t)
 		}
-		return applyNullable("("+strings.Join(parts, " | ")+")", o), true
+		return applyNullable(unionOf(parts...), o), true
 	}
-	return "", false
+	return nil, false
 }
 
-func schemaCombinatorToTS(doc *Document, o map[string]any, depth int, ctx *enumContext, nameHint string, mode schemaMode) (string, bool) {
+func schemaCombinatorToType(doc *Document, o map[string]any, depth int, ctx *enumContext, nameHint string, mode schemaMode) (*TypeNode, bool) {
 	if oneOf := anySlice(o["oneOf"]); len(oneOf) > 0 {
-		return applyNullable(schemaListToTS(doc, oneOf, depth, ctx, nameHint, "OneOf", " | ", mode), o), true
+		union := schemaListToType(doc, oneOf, depth, ctx, nameHint, "OneOf", TypeUnion, mode)
+		return applyNullable(discriminatedUnion(doc, o, oneOf, union), o), true
 	}
 	if anyOf := anySlice(o["anyOf"]); len(anyOf) > 0 {
-		return applyNullable(schemaListToTS(doc, anyOf, depth, ctx, nameHint, "AnyOf", " | ", mode), o), true
+		union := schemaListToType(doc, anyOf, depth, ctx, nameHint, "AnyOf", TypeUnion, mode)
+		return applyNullable(discriminatedUnion(doc, o, anyOf, union), o), true
 	}
 	if allOf := anySlice(o["allOf"]); len(allOf) > 0 {
-		return applyNullable(schemaListToTS(doc, allOf, depth, ctx, nameHint, "AllOf", " & ", mode), o), true
+		return applyNullable(schemaListToType(doc, allOf, depth, ctx, nameHint, "AllOf", TypeIntersection, mode), o), true
 	}
-	return "", false
+	return nil, false
 }
 
-func schemaListToTS(doc *Document, items []any, depth int, ctx *enumContext, nameHint, hintPrefix, joiner string, mode schemaMode) string {
-	parts := make([]string, 0, len(items))
+func schemaListToType(doc *Document, items []any, depth int, ctx *enumContext, nameHint, hintPrefix string, kind TypeKind, mode schemaMode) *TypeNode {
+	keyword := strings.ToLower(hintPrefix[:1]) + hintPrefix[1:]
+	parts := make([]*TypeNode, 0, len(items))
 	for i, it := range items {
-		parts = append(parts, schemaAnyToTS(doc, it, depth+1, ctx, joinEnumHint(nameHint, hintPrefix+strconv.Itoa(i+1)), mode))
+		leave := ctx.enter(keyword, strconv.Itoa(i))
+		parts = append(parts, schemaAnyToType(doc, it, depth+1, ctx, joinEnumHint(nameHint, hintPrefix+strconv.Itoa(i+1)), mode))
+		leave()
 	}
-	return "(" + strings.Join(parts, joiner) + ")"
+	return &TypeNode{Kind: kind, Items: parts}
 }
 
-func schemaTypeToTS(doc *Document, o map[string]any, depth int, ctx *enumContext, nameHint string, mode schemaMode) string {
+func schemaTypeToType(doc *Document, o map[string]any, depth int, ctx *enumContext, nameHint string, mode schemaMode) *TypeNode {
+	if types := anySlice(o["type"]); len(types) > 0 {
+		return applyNullable(schemaTypeListToType(doc, o, types, depth, ctx, nameHint, mode), o)
+	}
 	t, _ := o["type"].(string)
 	switch t {
 	case schemaTypeString:
-		return applyNullable(schemaTypeString, o)
+		if t, ok := ctx.brandType(o, ""); ok {
+			return t
+		}
+		return applyNullable(withSchema(ctx.formatType(o, schemaTypeString), o), o)
 	case "number", "integer":
-		return applyNullable("number", o)
+		if t, ok := ctx.brandType(o, ""); ok {
+			return t
+		}
+		return applyNullable(withSchema(ctx.formatType(o, "number"), o), o)
 	case "boolean":
-		return applyNullable("boolean", o)
+		return applyNullable(primitiveType("boolean"), o)
 	case schemaTypeNull:
-		return schemaTypeNull
+		return nullType()
 	case "array":
-		items := o["items"]
-		if items == nil {
-			return applyNullable(tsUnknown+"[]", o)
-		}
-		return applyNullable(schemaAnyToTS(doc, items, depth+1, ctx, joinEnumHint(nameHint, "Item"), mode)+"[]", o)
+		return applyNullable(withSchema(arrayToType(doc, o, depth, ctx, nameHint, mode), o), o)
 	case "object":
-		return applyNullable(objectToTS(doc, o, depth+1, ctx, nameHint, mode), o)
+		return applyNullable(withSchema(objectToType(doc, o, depth+1, ctx, nameHint, mode), o), o)
 	case "":
 		if props, ok := o["properties"].(map[string]any); ok && len(props) > 0 {
-			return applyNullable(objectToTS(doc, o, depth+1, ctx, nameHint, mode), o)
+			return applyNullable(withSchema(objectToType(doc, o, depth+1, ctx, nameHint, mode), o), o)
 		}
 		if req := anySlice(o["required"]); len(req) > 0 {
-			return applyNullable(objectToTS(doc, o, depth+1, ctx, nameHint, mode), o)
+			return applyNullable(withSchema(objectToType(doc, o, depth+1, ctx, nameHint, mode), o), o)
 		}
-		if o["items"] != nil {
-			return applyNullable(schemaAnyToTS(doc, o["items"], depth+1, ctx, joinEnumHint(nameHint, "Item"), mode)+"[]", o)
+		if o["items"] != nil || o["prefixItems"] != nil {
+			return applyNullable(withSchema(arrayToType(doc, o, depth, ctx, nameHint, mode), o), o)
 		}
-		return applyNullable(tsUnknown, o)
+		return applyNullable(unknownType(), o)
 	default:
-		return applyNullable(tsUnknown, o)
+		ctx.warn("unsupported type %q, emitted unknown", t)
+		return applyNullable(unknownType(), o)
 	}
 }
 
-func securitySchemeToTS(s *SecurityScheme) string {
+func arrayToType(doc *Document, o map[string]any, depth int, ctx *enumContext, nameHint string, mode schemaMode) *TypeNode {
+	prefixKey, restKey := "prefixItems", "items"
+	prefix := anySlice(o["prefixItems"])
+	if prefix == nil {
+		if items, ok := o["items"].([]any); ok {
+			prefixKey, restKey, prefix = "items", "additionalItems", items
+		}
+	}
+	minItems, _ := schemaInt(o["minItems"])
+	maxItems, hasMax := schemaInt(o["maxItems"])
+
+	rest := func() *TypeNode {
+		defer ctx.enter(restKey)()
+		return schemaAnyToType(doc, o[restKey], depth+1, ctx, joinEnumHint(nameHint, "Item"), mode)
+	}
+	closed := o[restKey] == false || (hasMax && maxItems <= len(prefix))
+
+	if prefix == nil {
+		if closed {
+			return tupleType()
+		}
+		item := unknownType()
+		if o["items"] != nil {
+			item = rest()
+		}
+		if ctx.fixedTuples && hasMax && minItems == maxItems && maxItems <= maxFixedTuple {
+			items := make([]*TypeNode, maxItems)
+			for i := range items {
+				items[i] = item
+			}
+			return tupleType(items...)
+		}
+		return arrayType(item)
+	}
+
+	n := len(prefix)
+	if hasMax && maxItems < n {
+		n = maxItems
+	}
+	items := make([]*TypeNode, 0, n+1)
+	for i, it := range prefix[:n] {
+		leave := ctx.enter(prefixKey, strconv.Itoa(i))
+		t := schemaAnyToType(doc, it, depth+1, ctx, joinEnumHint(nameHint, "Item"+strconv.Itoa(i+1)), mode)
+		leave()
+		if i >= minItems {
+			t = optionalType(t)
+		}
+		items = append(items, t)
+	}
+	if !closed {
+		item := unknownType()
+		if o[restKey] != nil {
+			item = rest()
+		}
+		items = append(items, restType(item))
+	}
+	return tupleType(items...)
+}
+
+func schemaInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		if n == float64(int(n)) {
+			return int(n), true
+		}
+	}
+	return 0, false
+}
+
+func schemaTypeListToType(doc *Document, o map[string]any, types []any, depth int, ctx *enumContext, nameHint string, mode schemaMode) *TypeNode {
+	parts := make([]*TypeNode, 0, len(types))
+	for _, it := range types {
+		t, ok := it.(string)
+		if !ok {
+			ctx.warn("unsupported type %v in type array, skipped", it)
+			continue
+		}
+		variant := make(map[string]any, len(o))
+		for k, v := range o {
+			if k != "nullable" {
+				variant[k] = v
+			}
+		}
+		variant["type"] = t
+		parts = append(parts, schemaTypeToType(doc, variant, depth, ctx, nameHint, mode))
+	}
+	return unionTypes(parts)
+}
+
+func securitySchemeToType(s *SecurityScheme) *TypeNode {
 	if s == nil {
-		return tsUnknown
+		return unknownType()
 	}
 	switch s.Type {
 	case "apiKey":
@@ -866,11 +1193,11 @@ func securitySchemeToTS(s *SecurityScheme) string {
 		}
 		return securitySchemeObject(fields, s.Description)
 	case "oauth2":
-		fields := map[string]string{
-			"type":  tsStringLiteralOrString("oauth2"),
-			"flows": oauthFlowsToTS(s.Flows),
+		fields := map[string]*TypeNode{
+			"type":  stringLiteralOrString("oauth2"),
+			"flows": oauthFlowsToType(s.Flows),
 		}
-		return securitySchemeObjectTS(fields, s.Description)
+		return securitySchemeObjectType(fields, s.Description)
 	case "openIdConnect":
 		fields := map[string]string{
 			"type":             "openIdConnect",
@@ -878,196 +1205,215 @@ func securitySchemeToTS(s *SecurityScheme) string {
 		}
 		return securitySchemeObject(fields, s.Description)
 	default:
-		fields := map[string]string{}
+		fields := map[string]*TypeNode{}
 		if s.Type != "" {
-			fields["type"] = s.Type
+			fields["type"] = stringLiteralOrString(s.Type)
 		}
 		if s.Name != "" {
-			fields["name"] = s.Name
+			fields["name"] = stringLiteralOrString(s.Name)
 		}
 		if s.In != "" {
-			fields["in"] = s.In
+			fields["in"] = stringLiteralOrString(s.In)
 		}
 		if s.Scheme != "" {
-			fields["scheme"] = s.Scheme
+			fields["scheme"] = stringLiteralOrString(s.Scheme)
 		}
 		if s.BearerFormat != "" {
-			fields["bearerFormat"] = s.BearerFormat
+			fields["bearerFormat"] = stringLiteralOrString(s.BearerFormat)
 		}
 		if s.OpenIDConnectURL != "" {
-			fields["openIdConnectUrl"] = s.OpenIDConnectURL
+			fields["openIdConnectUrl"] = stringLiteralOrString(s.OpenIDConnectURL)
 		}
 		if s.Flows != nil {
-			fields["flows"] = oauthFlowsToTS(s.Flows)
+			fields["flows"] = oauthFlowsToType(s.Flows)
 		}
 		if len(fields) == 0 {
-			return tsRecordUnknown
+			return recordType(stringType(), unknownType())
 		}
-		return securitySchemeObjectTS(fields, s.Description)
+		return securitySchemeObjectType(fields, s.Description)
 	}
 }
 
-func securitySchemeObject(fields map[string]string, description string) string {
-	out := map[string]string{}
+func securitySchemeObject(fields map[string]string, description string) *TypeNode {
+	out := map[string]*TypeNode{}
 	for k, v := range fields {
-		out[k] = tsStringLiteralOrString(v)
+		out[k] = stringLiteralOrString(v)
 	}
-	return securitySchemeObjectTS(out, description)
+	return securitySchemeObjectType(out, description)
 }
 
-func securitySchemeObjectTS(fields map[string]string, description string) string {
+func securitySchemeObjectType(fields map[string]*TypeNode, description string) *TypeNode {
 	keys := make([]string, 0, len(fields))
 	for k := range fields {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 
-	var b strings.Builder
-	b.WriteString("{\n")
+	out := make([]Field, 0, len(keys)+1)
 	if description != "" {
-		b.WriteString("  description?: string;\n")
+		out = append(out, Field{Name: "description", Type: stringType(), Optional: true})
 	}
 	for _, k := range keys {
-		writeTSObjectField(&b, "  ", safeProp(k), fields[k])
+		out = append(out, Field{Name: k, Type: fields[k]})
 	}
-	b.WriteString("}")
-	return b.String()
+	return objectType(out)
 }
 
-func oauthFlowsToTS(f *OAuthFlows) string {
+func oauthFlowsToType(f *OAuthFlows) *TypeNode {
 	if f == nil {
-		return tsUnknown
+		return unknownType()
 	}
-	fields := map[string]string{}
+	fields := []Field{}
 	if f.Implicit != nil {
-		fields["implicit"] = oauthFlowToTS(f.Implicit)
+		fields = append(fields, Field{Name: "implicit", Type: oauthFlowToType(f.Implicit)})
 	}
 	if f.Password != nil {
-		fields["password"] = oauthFlowToTS(f.Password)
+		fields = append(fields, Field{Name: "password", Type: oauthFlowToType(f.Password)})
 	}
 	if f.ClientCredentials != nil {
-		fields["clientCredentials"] = oauthFlowToTS(f.ClientCredentials)
+		fields = append(fields, Field{Name: "clientCredentials", Type: oauthFlowToType(f.ClientCredentials)})
 	}
 	if f.AuthorizationCode != nil {
-		fields["authorizationCode"] = oauthFlowToTS(f.AuthorizationCode)
-	}
-	if len(fields) == 0 {
-		return "{}"
-	}
-	keys := make([]string, 0, len(fields))
-	for k := range fields {
-		keys = append(keys, k)
+		fields = append(fields, Field{Name: "authorizationCode", Type: oauthFlowToType(f.AuthorizationCode)})
 	}
-	sort.Strings(keys)
-	var b strings.Builder
-	b.WriteString("{\n")
-	for _, k := range keys {
-		writeTSObjectField(&b, "  ", safeProp(k), fields[k])
-	}
-	b.WriteString("}")
-	return b.String()
+	return sortedObjectType(fields)
 }
 
-func oauthFlowToTS(f *OAuthFlow) string {
+func oauthFlowToType(f *OAuthFlow) *TypeNode {
 	if f == nil {
-		return tsUnknown
+		return unknownType()
 	}
-	var b strings.Builder
-	b.WriteString("{\n")
+	fields := []Field{}
 	if f.AuthorizationURL != "" {
-		b.WriteString("  authorizationUrl: string;\n")
+		fields = append(fields, Field{Name: "authorizationUrl", Type: stringType()})
 	}
 	if f.TokenURL != "" {
-		b.WriteString("  tokenUrl: string;\n")
+		fields = append(fields, Field{Name: "tokenUrl", Type: stringType()})
 	}
 	if f.RefreshURL != "" {
-		b.WriteString("  refreshUrl: string;\n")
+		fields = append(fields, Field{Name: "refreshUrl", Type: stringType()})
 	}
 	if len(f.Scopes) > 0 {
-		keys := make([]string, 0, len(f.Scopes))
+		scopes := make([]Field, 0, len(f.Scopes))
 		for k := range f.Scopes {
-			keys = append(keys, k)
+			scopes = append(scopes, Field{Name: k, Type: stringType()})
 		}
-		sort.Strings(keys)
-		b.WriteString("  scopes: {\n")
-		for _, k := range keys {
-			b.WriteString("    " + safeProp(k) + ": string;\n")
-		}
-		b.WriteString("  };\n")
+		fields = append(fields, Field{Name: "scopes", Type: sortedObjectType(scopes)})
 	} else {
-		b.WriteString("  scopes: Record<string, string>;\n")
+		fields = append(fields, Field{Name: "scopes", Type: recordType(stringType(), stringType())})
 	}
-	b.WriteString("}")
-	return b.String()
+	return objectType(fields)
 }
 
-func tsStringLiteralOrString(v string) string {
+func stringLiteralOrString(v string) *TypeNode {
 	if v == "" {
-		return schemaTypeString
+		return stringType()
 	}
-	return strconv.Quote(v)
+	return literalType(v)
 }
 
-func literalToTS(v any) string {
-	switch val := v.(type) {
-	case string:
-		return strconv.Quote(val)
-	case bool:
-		if val {
-			return "true"
-		}
-		return "false"
-	case int:
-		return strconv.Itoa(val)
-	case int64:
-		return strconv.FormatInt(val, 10)
-	case int32:
-		return strconv.FormatInt(int64(val), 10)
-	case float64:
-		return strconv.FormatFloat(val, 'f', -1, 64)
-	case float32:
-		return strconv.FormatFloat(float64(val), 'f', -1, 32)
-	case nil:
-		return schemaTypeNull
-	default:
-		return ""
-	}
+func withSchema(t *TypeNode, o map[string]any) *TypeNode {
+	t.Schema = o
+	return t
 }
 
-func applyNullable(ts string, o map[string]any) string {
+func applyNullable(t *TypeNode, o map[string]any) *TypeNode {
 	if o == nil {
-		return ts
+		return t
 	}
 	if v, ok := o["nullable"]; ok {
-		if b, ok := v.(bool); ok && b && ts != schemaTypeNull {
-			return "(" + ts + " | " + schemaTypeNull + ")"
+		if b, ok := v.(bool); ok && b && !isPrimitive(t, schemaTypeNull) {
+			return unionOf(t, nullType())
 		}
 	}
-	return ts
+	return t
 }
 
 type enumContext struct {
-	enums map[string]string
-	used  map[string]bool
+	enums       map[string]IREnum
+	used        map[string]bool
+	formats     map[string]string
+	brands      map[string]IRBrand
+	callbacks   map[string]bool
+	access      map[string]bool
+	inlining    map[string]bool
+	diags       *diagnostics
+	order       *keyOrder
+	fixedTuples bool
+}
+
+func formatTypes(overrides map[string]string) map[string]string {
+	out := make(map[string]string, len(DefaultFormats)+len(overrides))
+	for k, v := range DefaultFormats {
+		out[k] = v
+	}
+	for k, v := range overrides {
+		out[k] = v
+	}
+	return out
 }
 
-func newEnumContext(enums map[string]string) *enumContext {
+func (c *enumContext) formatType(o map[string]any, fallback string) *TypeNode {
+	format, _ := o["format"].(string)
+	if c == nil || format == "" {
+		return primitiveType(fallback)
+	}
+	ts := strings.TrimSpace(c.formats[format])
+	switch {
+	case ts == "":
+		return primitiveType(fallback)
+	case isTSIdent(ts):
+		return primitiveType(ts)
+	default:
+		return primitiveType("(" + ts + ")")
+	}
+}
+
+func (c *enumContext) inline(name string) (func(), bool) {
+	if c == nil {
+		return func() {}, true
+	}
+	if c.inlining[name] {
+		return nil, false
+	}
+	if c.inlining == nil {
+		c.inlining = map[string]bool{}
+	}
+	c.inlining[name] = true
+	return func() { delete(c.inlining, name) }, true
+}
+
+func (c *enumContext) configure(doc *Document, opts IROptions, brands map[string]IRBrand) {
+	c.formats = formatTypes(opts.Formats)
+	c.fixedTuples = opts.FixedTuples
+	if opts.Brands {
+		c.brands = brands
+	}
+	if opts.PreserveOrder {
+		c.order = newKeyOrder(doc.source)
+	}
+}
+
+func newEnumContext(enums map[string]IREnum) *enumContext {
 	if enums == nil {
-		enums = map[string]string{}
+		enums = map[string]IREnum{}
 	}
 	used := map[string]bool{
 		"Components": true,
 		"Routes":     true,
+		"Operations": true,
+		"Webhooks":   true,
+		"Servers":    true,
 	}
 	for name := range enums {
 		used[name] = true
 	}
-	return &enumContext{enums: enums, used: used}
+	return &enumContext{enums: enums, used: used, callbacks: map[string]bool{}}
 }
 
-func (c *enumContext) emitEnum(nameHint string, values []any, o map[string]any) string {
+func (c *enumContext) emitEnum(nameHint string, values []any, o map[string]any) *TypeNode {
 	if c == nil || len(values) == 0 {
-		return ""
+		return nil
 	}
 	_, nullable, _ := schemaEnumValues(o)
 	base := enumBaseNameFromHint(nameHint, values)
@@ -1080,17 +1426,18 @@ func (c *enumContext) emitEnum(nameHint string, values []any, o map[string]any)
 		}
 		c.used[enumName] = true
 	}
-	kind, members, ok := enumMembers(values)
+	names, descs := c.enumMemberExtensions(o, len(values))
+	members, ok := enumMembers(values, names, descs)
 	if !ok || len(members) == 0 {
-		return ""
+		return nil
 	}
 	if _, exists := c.enums[enumName]; !exists {
-		c.enums[enumName] = emitEnumTS(enumName, members, kind)
+		c.enums[enumName] = IREnum{Name: enumName, Members: members}
 	}
 	if nullable {
-		return "(" + enumName + " | null)"
+		return unionOf(enumType(enumName), nullType())
 	}
-	return enumName
+	return enumType(enumName)
 }
 
 func joinEnumHint(base, part string) string {
@@ -1148,56 +1495,70 @@ func schemaEnumValues(o map[string]any) (values []any, nullable, ok bool) {
 
 type enumKind int
 
-type enumMember struct {
-	Name  string
-	Value string
+func (c *enumContext) enumMemberExtensions(o map[string]any, n int) (names, descs []string) {
+	key := "x-enum-varnames"
+	if _, ok := o[key]; !ok {
+		key = "x-enumNames"
+	}
+	names = c.enumExtensionStrings(o, key, n)
+	descs = c.enumExtensionStrings(o, "x-enum-descriptions", n)
+	for i, name := range names {
+		if name != "" && !isIdent(name) {
+			c.warn("%s entry %q is not a valid identifier, derived the member name from its value", key, name)
+			names[i] = ""
+		}
+	}
+	return names, descs
 }
 
-func enumMembers(values []any) (enumKind, []enumMember, bool) {
+func (c *enumContext) enumExtensionStrings(o map[string]any, key string, n int) []string {
+	raw, ok := o[key]
+	if !ok {
+		return nil
+	}
+	items := anySlice(raw)
+	if len(items) != n {
+		c.warn("%s has %d entries for %d enum values, ignored", key, len(items), n)
+		return nil
+	}
+	out := make([]string, n)
+	for i, it := range items {
+		if s, ok := it.(string); ok {
+			out[i] = s
+		}
+	}
+	return out
+}
+
+func enumMembers(values []any, names, descs []string) ([]EnumMember, bool) {
 	if len(values) == 0 {
-		return enumInvalid, nil, false
+		return nil, false
 	}
 	kind := enumInvalid
 	seenNames := map[string]int{}
-	out := make([]enumMember, 0, len(values))
-	for _, v := range values {
+	out := make([]EnumMember, 0, len(values))
+	for i, v := range values {
 		var (
 			memberName string
-			memberVal  string
 			valKind    enumKind
 		)
 		switch val := v.(type) {
 		case string:
 			valKind = enumString
-			memberVal = strconv.Quote(val)
 			memberName = enumMemberNameFromString(val)
-		case int:
-			valKind = enumNumber
-			memberVal = strconv.Itoa(val)
-			memberName = enumMemberNameFromNumber(memberVal)
-		case int64:
-			valKind = enumNumber
-			memberVal = strconv.FormatInt(val, 10)
-			memberName = enumMemberNameFromNumber(memberVal)
-		case int32:
-			valKind = enumNumber
-			memberVal = strconv.FormatInt(int64(val), 10)
-			memberName = enumMemberNameFromNumber(memberVal)
-		case float64:
+		case int, int64, int32, float64, float32:
 			valKind = enumNumber
-			memberVal = strconv.FormatFloat(val, 'f', -1, 64)
-			memberName = enumMemberNameFromNumber(memberVal)
-		case float32:
-			valKind = enumNumber
-			memberVal = strconv.FormatFloat(float64(val), 'f', -1, 32)
-			memberName = enumMemberNameFromNumber(memberVal)
+			memberName = enumMemberNameFromNumber(literalToTS(val))
 		default:
-			return enumInvalid, nil, false
+			return nil, false
 		}
 		if kind == enumInvalid {
 			kind = valKind
 		} else if kind != valKind {
-			return enumInvalid, nil, false
+			return nil, false
+		}
+		if i < len(names) && names[i] != "" {
+			memberName = names[i]
 		}
 		if memberName == "" || !isIdent(memberName) {
 			memberName = enumValuePrefix
@@ -1208,19 +1569,13 @@ func enumMembers(values []any) (enumKind, []enumMember, bool) {
 		} else {
 			seenNames[memberName] = 1
 		}
-		out = append(out, enumMember{Name: memberName, Value: memberVal})
-	}
-	return kind, out, true
-}
-
-func emitEnumTS(name string, members []enumMember, _ enumKind) string {
-	var b strings.Builder
-	b.WriteString("export const enum " + name + " {\n")
-	for _, m := range members {
-		b.WriteString("  " + m.Name + " = " + m.Value + ",\n")
+		member := EnumMember{Name: memberName, Value: v}
+		if i < len(descs) {
+			member.Doc = appendDocText(nil, "@description", descs[i])
+		}
+		out = append(out, member)
 	}
-	b.WriteString("}\n\n")
-	return b.String()
+	return out, true
 }
 
 func sanitizeIdent(s string) string {
@@ -1423,72 +1778,102 @@ func includeProperty(o map[string]any, mode schemaMode) bool {
 	return true
 }
 
-func writeTSObjectField(b *strings.Builder, indent, key, value string) {
-	lines := strings.Split(value, "\n")
-	if len(lines) == 1 {
-		b.WriteString(indent + key + ": " + value + ";\n")
-		return
+func (c *enumContext) inlinesRef(doc *Document, name string) bool {
+	return c == nil || c.access == nil || c.hasAccessFlags(doc, name, map[string]bool{})
+}
+
+func (c *enumContext) hasAccessFlags(doc *Document, name string, seen map[string]bool) bool {
+	if v, ok := c.access[name]; ok {
+		return v
 	}
-	b.WriteString(indent + key + ": " + lines[0] + "\n")
-	for i := 1; i < len(lines); i++ {
-		if i == len(lines)-1 {
-			b.WriteString(indent + lines[i] + ";\n")
-			continue
+	if seen[name] || doc == nil || doc.Components == nil {
+		return false
+	}
+	seen[name] = true
+	sch, ok := doc.Components.Schemas[name]
+	if !ok {
+		return false
+	}
+	found := false
+	var walk func(v any)
+	walk = func(v any) {
+		if found {
+			return
+		}
+		switch val := v.(type) {
+		case map[string]any:
+			if b, ok := val["readOnly"].(bool); ok && b {
+				found = true
+				return
+			}
+			if b, ok := val["writeOnly"].(bool); ok && b {
+				found = true
+				return
+			}
+			if ref, ok := val["$ref"].(string); ok {
+				if dep, ok := refComponentName(ref, "schemas"); ok && c.hasAccessFlags(doc, dep, seen) {
+					found = true
+					return
+				}
+			}
+			for _, it := range val {
+				walk(it)
+			}
+		case []any:
+			for _, it := range val {
+				walk(it)
+			}
 		}
-		b.WriteString(indent + lines[i] + "\n")
 	}
+	walk(sch.Other)
+	c.access[name] = found
+	return found
 }
 
-func schemaAnyToTS(doc *Document, v any, depth int, ctx *enumContext, nameHint string, mode schemaMode) string {
+func schemaAnyToType(doc *Document, v any, depth int, ctx *enumContext, nameHint string, mode schemaMode) *TypeNode {
 	if v == nil {
-		return tsUnknown
+		return unknownType()
 	}
-	if depth > 30 {
-		return tsUnknown
+	if depth > maxSchemaDepth {
+		ctx.warnDepth()
+		return unknownType()
 	}
 	if m, ok := v.(map[string]any); ok {
 		if ref, ok := m["$ref"].(string); ok && ref != "" {
-			return schemaToTS(doc, &RefOr[Schema]{Ref: ref}, depth+1, ctx, nameHint, mode)
+			return schemaToType(doc, &RefOr[Schema]{Ref: ref}, depth+1, ctx, nameHint, mode)
 		}
 		r := &RefOr[Schema]{Value: &Schema{Other: m}}
-		return schemaToTS(doc, r, depth+1, ctx, nameHint, mode)
+		return schemaToType(doc, r, depth+1, ctx, nameHint, mode)
 	}
-	return tsUnknown
+	return unknownType()
 }
 
-func objectToTS(doc *Document, o map[string]any, depth int, ctx *enumContext, nameHint string, mode schemaMode) string {
+func objectToType(doc *Document, o map[string]any, depth int, ctx *enumContext, nameHint string, mode schemaMode) *TypeNode {
 	props, _ := o["properties"].(map[string]any)
 	req := stringSet(anySlice(o["required"]))
 	depConstraints := dependentRequiredConstraints(doc, o, props, req, depth, ctx, nameHint, mode)
 
-	extraInfo, hasExtra := extraPropsToTS(doc, o, depth, ctx, nameHint, mode)
-	extraTS := renderExtraProps(extraInfo)
+	extraInfo, hasExtra := extraPropsToType(doc, o, depth, ctx, nameHint, mode)
+	extra := extraPropsType(extraInfo)
 	if len(props) == 0 {
 		if len(req) > 0 {
-			keys := make([]string, 0, len(req))
+			fields := make([]Field, 0, len(req))
 			for k := range req {
-				keys = append(keys, k)
-			}
-			sort.Strings(keys)
-			var b strings.Builder
-			b.WriteString("{\n")
-			for _, k := range keys {
-				b.WriteString("  " + safeProp(k) + ": " + tsUnknown + ";\n")
+				fields = append(fields, Field{Name: k, Type: unknownType()})
 			}
-			b.WriteString("}")
-			base := b.String()
-			if hasExtra && extraTS != "" {
-				return "(" + base + " & " + extraTS + ")"
+			base := sortedObjectType(fields)
+			if hasExtra && extra != nil {
+				return intersectionOf(base, extra)
 			}
 			return base
 		}
-		if hasExtra && extraTS != "" {
-			return extraTS
+		if hasExtra && extra != nil {
+			return extra
 		}
 		if extraInfo.additionalFalse {
-			return "Record<string, never>"
+			return recordType(stringType(), neverType())
 		}
-		return tsRecordUnknown
+		return recordType(stringType(), unknownType())
 	}
 
 	keys := make([]string, 0, len(props))
@@ -1497,148 +1882,122 @@ func objectToTS(doc *Document, o map[string]any, depth int, ctx *enumContext, na
 	}
 	sort.Strings(keys)
 
-	var b strings.Builder
-	b.WriteString("{\n")
+	fields := make([]Field, 0, len(keys))
 	for _, k := range keys {
 		propMap, _ := props[k].(map[string]any)
 		if !includeProperty(propMap, mode) {
 			continue
 		}
-		ts := schemaAnyToTS(doc, props[k], depth+1, ctx, joinEnumHint(nameHint, k), mode)
-		if req[k] {
-			b.WriteString("  " + safeProp(k) + ": " + ts + ";\n")
-		} else {
-			b.WriteString("  " + safeProp(k) + "?: " + ts + ";\n")
-		}
+		leave := ctx.enter("properties", k)
+		fields = append(fields, Field{
+			Name:     k,
+			Type:     schemaAnyToType(doc, props[k], depth+1, ctx, joinEnumHint(nameHint, k), mode),
+			Doc:      schemaDoc(propMap),
+			Optional: !req[k],
+		})
+		leave()
 	}
-	b.WriteString("}")
-	base := b.String()
-	if hasExtra && extraTS != "" {
-		base = "(" + base + " & " + extraTS + ")"
+	ctx.orderFields(fields)
+	base := objectType(fields)
+	if hasExtra && extra != nil {
+		base = intersectionOf(base, extra)
 	}
 
 	if len(depConstraints) > 0 {
-		base = "(" + base + " & " + strings.Join(depConstraints, " & ") + ")"
+		base = intersectionOf(append([]*TypeNode{base}, depConstraints...)...)
 	}
 
 	ifSchema, hasIf := o["if"]
 	thenSchema, hasThen := o["then"]
 	elseSchema, hasElse := o["else"]
 	if hasIf || hasThen || hasElse {
-		if ts := ifThenElseToTS(doc, props, base, ifSchema, thenSchema, elseSchema, depth, ctx, nameHint, mode, hasIf, hasThen, hasElse); ts != "" {
-			return ts
+		if t := ifThenElseToType(doc, props, base, ifSchema, thenSchema, elseSchema, depth, ctx, nameHint, mode, hasIf, hasThen, hasElse); t != nil {
+			return t
 		}
 	}
 	return base
 }
 
-type fieldSpec struct {
-	Name     string
-	TS       string
-	Optional bool
-}
-
-func objectTypeFromFields(fields []fieldSpec) string {
-	if len(fields) == 0 {
-		return "{}"
-	}
-	sort.Slice(fields, func(i, j int) bool {
-		return fields[i].Name < fields[j].Name
-	})
-	var b strings.Builder
-	b.WriteString("{\n")
-	for _, f := range fields {
-		if f.Optional {
-			b.WriteString("  " + safeProp(f.Name) + "?: " + f.TS + ";\n")
-		} else {
-			b.WriteString("  " + safeProp(f.Name) + ": " + f.TS + ";\n")
-		}
-	}
-	b.WriteString("}")
-	return b.String()
-}
-
-func propertySchemaToTS(doc *Document, props map[string]any, name string, depth int, ctx *enumContext, nameHint string, mode schemaMode) string {
+func propertySchemaToType(doc *Document, props map[string]any, name string, depth int, ctx *enumContext, nameHint string, mode schemaMode) *TypeNode {
 	if props == nil {
-		return tsUnknown
+		return unknownType()
 	}
 	if v, ok := props[name]; ok {
-		return schemaAnyToTS(doc, v, depth+1, ctx, joinEnumHint(nameHint, name), mode)
+		return schemaAnyToType(doc, v, depth+1, ctx, joinEnumHint(nameHint, name), mode)
 	}
-	return tsUnknown
+	return unknownType()
 }
 
-func dependentRequiredConstraints(doc *Document, o, props map[string]any, req map[string]bool, depth int, ctx *enumContext, nameHint string, mode schemaMode) []string {
+func dependentRequiredConstraints(doc *Document, o, props map[string]any, req map[string]bool, depth int, ctx *enumContext, nameHint string, mode schemaMode) []*TypeNode {
 	dr, ok := o["dependentRequired"].(map[string]any)
 	if !ok || len(dr) == 0 {
 		return nil
 	}
 
-	out := make([]string, 0, len(dr))
-	for key, v := range dr {
-		names, ok := v.([]any)
+	keys := make([]string, 0, len(dr))
+	for k := range dr {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make([]*TypeNode, 0, len(dr))
+	for _, key := range keys {
+		names, ok := dr[key].([]any)
 		if !ok || len(names) == 0 {
 			continue
 		}
-		depFields := make([]fieldSpec, 0, len(names))
+		depFields := make([]Field, 0, len(names))
 		for _, it := range names {
 			s, ok := it.(string)
 			if !ok {
 				continue
 			}
-			depFields = append(depFields, fieldSpec{
-				Name:     s,
-				TS:       propertySchemaToTS(doc, props, s, depth, ctx, nameHint, mode),
-				Optional: false,
+			depFields = append(depFields, Field{
+				Name: s,
+				Type: propertySchemaToType(doc, props, s, depth, ctx, nameHint, mode),
 			})
 		}
 		if len(depFields) == 0 {
 			continue
 		}
 
-		keyTS := propertySchemaToTS(doc, props, key, depth, ctx, nameHint, mode)
+		keyType := propertySchemaToType(doc, props, key, depth, ctx, nameHint, mode)
 		if req[key] {
-			fields := append([]fieldSpec{{Name: key, TS: keyTS, Optional: false}}, depFields...)
-			out = append(out, objectTypeFromFields(fields))
+			fields := append([]Field{{Name: key, Type: keyType}}, depFields...)
+			out = append(out, sortedObjectType(fields))
 			continue
 		}
 
-		absentFields := []fieldSpec{{Name: key, TS: tsNever, Optional: true}}
-		requiredFields := append([]fieldSpec{{Name: key, TS: keyTS, Optional: false}}, depFields...)
-		out = append(out, "("+objectTypeFromFields(absentFields)+" | "+objectTypeFromFields(requiredFields)+")")
+		absentFields := []Field{{Name: key, Type: neverType(), Optional: true}}
+		requiredFields := append([]Field{{Name: key, Type: keyType}}, depFields...)
+		out = append(out, unionOf(sortedObjectType(absentFields), sortedObjectType(requiredFields)))
 	}
 
 	return out
 }
 
-func ifThenElseToTS(doc *Document, props map[string]any, base string, ifSchema, thenSchema, elseSchema any, depth int, ctx *enumContext, nameHint string, mode schemaMode, hasIf, hasThen, hasElse bool) string {
+func ifThenElseToType(doc *Document, props map[string]any, base *TypeNode, ifSchema, thenSchema, elseSchema any, depth int, ctx *enumContext, nameHint string, mode schemaMode, hasIf, hasThen, hasElse bool) *TypeNode {
 	ifProp, ifVals, ok := extractIfPropertyValues(ifSchema)
 	if ok {
 		baseEnum := propertyEnumLiterals(props, ifProp)
 		elseVals := subtractLiterals(baseEnum, ifVals)
 
-		parts := []string{}
+		parts := []*TypeNode{}
 		if hasThen {
 			thenPart := base
 			if len(ifVals) > 0 {
-				thenPart = "(" + thenPart + " & " + objectTypeFromFields([]fieldSpec{{Name: ifProp, TS: literalUnion(ifVals), Optional: false}}) + ")"
-			}
-			thenTS := schemaAnyToTS(doc, thenSchema, depth+1, ctx, joinEnumHint(nameHint, "Then"), mode)
-			if thenTS != "" {
-				thenPart = "(" + thenPart + " & " + thenTS + ")"
+				thenPart = intersectionOf(thenPart, objectType([]Field{{Name: ifProp, Type: literalUnion(ifVals)}}))
 			}
+			thenPart = intersectionOf(thenPart, schemaAnyToType(doc, thenSchema, depth+1, ctx, joinEnumHint(nameHint, "Then"), mode))
 			parts = append(parts, thenPart)
 		}
 		if hasElse || len(elseVals) > 0 {
 			elsePart := base
 			if len(elseVals) > 0 {
-				elsePart = "(" + elsePart + " & " + objectTypeFromFields([]fieldSpec{{Name: ifProp, TS: literalUnion(elseVals), Optional: false}}) + ")"
+				elsePart = intersectionOf(elsePart, objectType([]Field{{Name: ifProp, Type: literalUnion(elseVals)}}))
 			}
 			if hasElse {
-				elseTS := schemaAnyToTS(doc, elseSchema, depth+1, ctx, joinEnumHint(nameHint, "Else"), mode)
-				if elseTS != "" {
-					elsePart = "(" + elsePart + " & " + elseTS + ")"
-				}
+				elsePart = intersectionOf(elsePart, schemaAnyToType(doc, elseSchema, depth+1, ctx, joinEnumHint(nameHint, "Else"), mode))
 			}
 			parts = append(parts, elsePart)
 		}
@@ -1646,30 +2005,30 @@ func ifThenElseToTS(doc *Document, props map[string]any, base string, ifSchema,
 			return parts[0]
 		}
 		if len(parts) > 1 {
-			return "(" + strings.Join(parts, " | ") + ")"
+			return unionOf(parts...)
 		}
 	}
 
-	parts := []string{}
+	parts := []*TypeNode{}
 	if hasThen {
-		parts = append(parts, schemaAnyToTS(doc, thenSchema, depth+1, ctx, joinEnumHint(nameHint, "Then"), mode))
+		parts = append(parts, schemaAnyToType(doc, thenSchema, depth+1, ctx, joinEnumHint(nameHint, "Then"), mode))
 	}
 	if hasElse {
-		parts = append(parts, schemaAnyToTS(doc, elseSchema, depth+1, ctx, joinEnumHint(nameHint, "Else"), mode))
+		parts = append(parts, schemaAnyToType(doc, elseSchema, depth+1, ctx, joinEnumHint(nameHint, "Else"), mode))
 	}
 	if len(parts) == 0 && hasIf {
-		parts = append(parts, schemaAnyToTS(doc, ifSchema, depth+1, ctx, joinEnumHint(nameHint, "If"), mode))
+		parts = append(parts, schemaAnyToType(doc, ifSchema, depth+1, ctx, joinEnumHint(nameHint, "If"), mode))
 	}
 	if len(parts) == 1 {
-		return "(" + base + " & " + parts[0] + ")"
+		return intersectionOf(base, parts[0])
 	}
 	if len(parts) > 1 {
-		return "(" + base + " & (" + strings.Join(parts, " | ") + "))"
+		return intersectionOf(base, unionOf(parts...))
 	}
-	return ""
+	return nil
 }
 
-func extractIfPropertyValues(v any) (propName string, literals []string, ok bool) {
+func extractIfPropertyValues(v any) (propName string, literals []*TypeNode, ok bool) {
 	m, ok := v.(map[string]any)
 	if !ok {
 		return "", nil, false
@@ -1678,34 +2037,17 @@ func extractIfPropertyValues(v any) (propName string, literals []string, ok bool
 	if !ok || len(props) != 1 {
 		return "", nil, false
 	}
-	for name, raw := range props {
-		prop, ok := raw.(map[string]any)
-		if !ok {
-			return "", nil, false
-		}
-		if cv, ok := prop["const"]; ok {
-			if ts := literalToTS(cv); ts != "" {
-				return name, []string{ts}, true
-			}
-			return "", nil, false
-		}
-		if ev := anySlice(prop["enum"]); len(ev) > 0 {
-			lits := make([]string, 0, len(ev))
-			for _, it := range ev {
-				if ts := literalToTS(it); ts != "" {
-					lits = append(lits, ts)
-				}
-			}
-			if len(lits) > 0 {
-				return name, lits, true
-			}
+	for name := range props {
+		lits := propertyEnumLiterals(props, name)
+		if len(lits) == 0 {
 			return "", nil, false
 		}
+		return name, lits, true
 	}
 	return "", nil, false
 }
 
-func propertyEnumLiterals(props map[string]any, name string) []string {
+func propertyEnumLiterals(props map[string]any, name string) []*TypeNode {
 	if props == nil {
 		return nil
 	}
@@ -1718,16 +2060,16 @@ func propertyEnumLiterals(props map[string]any, name string) []string {
 		return nil
 	}
 	if cv, ok := prop["const"]; ok {
-		if ts := literalToTS(cv); ts != "" {
-			return []string{ts}
+		if t, ok := literalValue(cv); ok {
+			return []*TypeNode{t}
 		}
 		return nil
 	}
 	if ev := anySlice(prop["enum"]); len(ev) > 0 {
-		out := make([]string, 0, len(ev))
+		out := make([]*TypeNode, 0, len(ev))
 		for _, it := range ev {
-			if ts := literalToTS(it); ts != "" {
-				out = append(out, ts)
+			if t, ok := literalValue(it); ok {
+				out = append(out, t)
 			}
 		}
 		return out
@@ -1735,42 +2077,42 @@ func propertyEnumLiterals(props map[string]any, name string) []string {
 	return nil
 }
 
-func subtractLiterals(base, remove []string) []string {
+func subtractLiterals(base, remove []*TypeNode) []*TypeNode {
 	if len(base) == 0 {
 		return nil
 	}
 	rm := map[string]bool{}
 	for _, v := range remove {
-		rm[v] = true
+		rm[typeKey(v)] = true
 	}
-	out := make([]string, 0, len(base))
+	out := make([]*TypeNode, 0, len(base))
 	for _, v := range base {
-		if !rm[v] {
+		if !rm[typeKey(v)] {
 			out = append(out, v)
 		}
 	}
 	return out
 }
 
-func literalUnion(values []string) string {
+func literalUnion(values []*TypeNode) *TypeNode {
 	if len(values) == 0 {
-		return tsUnknown
+		return unknownType()
 	}
 	if len(values) == 1 {
 		return values[0]
 	}
-	return "(" + strings.Join(values, " | ") + ")"
+	return unionOf(values...)
 }
 
 type extraPropsInfo struct {
-	additionalValue   string
-	patternKeyTypes   []string
-	patternValueTypes []string
+	additionalValue   *TypeNode
+	patternKeyTypes   []*TypeNode
+	patternValueTypes []*TypeNode
 	additionalEnabled bool
 	additionalFalse   bool
 }
 
-func extraPropsToTS(doc *Document, o map[string]any, depth int, ctx *enumContext, nameHint string, mode schemaMode) (extraPropsInfo, bool) {
+func extraPropsToType(doc *Document, o map[string]any, depth int, ctx *enumContext, nameHint string, mode schemaMode) (extraPropsInfo, bool) {
 	info := extraPropsInfo{}
 
 	if pp, ok := o["patternProperties"].(map[string]any); ok && len(pp) > 0 {
@@ -1781,11 +2123,11 @@ func extraPropsToTS(doc *Document, o map[string]any, depth int, ctx *enumContext
 		sort.Strings(keys)
 		for i, k := range keys {
 			if m, ok := pp[k].(map[string]any); ok {
-				ts := schemaAnyToTS(doc, m, depth+1, ctx, joinEnumHint(nameHint, "Pattern"+strconv.Itoa(i+1)), mode)
-				if ts != "" {
-					info.patternValueTypes = append(info.patternValueTypes, ts)
-					info.patternKeyTypes = append(info.patternKeyTypes, patternKeyType(k))
-				}
+				leave := ctx.enter("patternProperties", k)
+				t := schemaAnyToType(doc, m, depth+1, ctx, joinEnumHint(nameHint, "Pattern"+strconv.Itoa(i+1)), mode)
+				leave()
+				info.patternValueTypes = append(info.patternValueTypes, t)
+				info.patternKeyTypes = append(info.patternKeyTypes, patternKeyType(k))
 			}
 		}
 	}
@@ -1795,16 +2137,15 @@ func extraPropsToTS(doc *Document, o map[string]any, depth int, ctx *enumContext
 		case bool:
 			if v {
 				info.additionalEnabled = true
-				info.additionalValue = tsUnknown
+				info.additionalValue = unknownType()
 			} else {
 				info.additionalFalse = true
 			}
 		case map[string]any:
-			ts := schemaAnyToTS(doc, v, depth+1, ctx, joinEnumHint(nameHint, "AdditionalProperties"), mode)
-			if ts != "" {
-				info.additionalEnabled = true
-				info.additionalValue = ts
-			}
+			info.additionalEnabled = true
+			leave := ctx.enter("additionalProperties")
+			info.additionalValue = schemaAnyToType(doc, v, depth+1, ctx, joinEnumHint(nameHint, "AdditionalProperties"), mode)
+			leave()
 		}
 	}
 
@@ -1812,97 +2153,93 @@ func extraPropsToTS(doc *Document, o map[string]any, depth int, ctx *enumContext
 	return info, hasExtra
 }
 
-func renderExtraProps(info extraPropsInfo) string {
+func extraPropsType(info extraPropsInfo) *TypeNode {
 	hasPattern := len(info.patternValueTypes) > 0
 	hasAdditional := info.additionalEnabled
 	if !hasPattern && !hasAdditional {
-		return ""
+		return nil
 	}
 
-	var patternTS string
-	var patternValue string
+	var pattern, patternValue *TypeNode
 	if hasPattern {
 		patternValue = unionTypes(info.patternValueTypes)
 		keyUnion := unionKeyTypes(info.patternKeyTypes)
-		if keyUnion == "" {
-			keyUnion = schemaTypeString
-		}
-		if keyUnion == schemaTypeString {
-			patternTS = "Record<" + schemaTypeString + ", " + patternValue + ">"
+		if keyUnion == nil || isPrimitive(keyUnion, schemaTypeString) {
+			pattern = recordType(stringType(), patternValue)
 		} else {
-			patternTS = "{ [K in " + keyUnion + "]?: " + patternValue + " }"
+			pattern = mappedType(keyUnion, patternValue)
 		}
 	}
 
 	if hasAdditional {
 		union := info.additionalValue
 		if hasPattern {
-			union = unionTypes([]string{union, patternValue})
+			union = unionTypes([]*TypeNode{union, patternValue})
 		}
-		base := "Record<" + schemaTypeString + ", " + union + ">"
+		base := recordType(stringType(), union)
 		if hasPattern {
-			return "(" + patternTS + " & " + base + ")"
+			return intersectionOf(pattern, base)
 		}
 		return base
 	}
 
-	return patternTS
+	return pattern
 }
 
-func unionTypes(items []string) string {
+func unionTypes(items []*TypeNode) *TypeNode {
 	seen := map[string]bool{}
-	out := make([]string, 0, len(items))
+	out := make([]*TypeNode, 0, len(items))
 	for _, it := range items {
-		if it == "" {
+		if it == nil {
 			continue
 		}
-		if it == tsUnknown {
-			return tsUnknown
+		if it.Kind == TypeUnknown {
+			return unknownType()
 		}
-		if !seen[it] {
-			seen[it] = true
+		if key := typeKey(it); !seen[key] {
+			seen[key] = true
 			out = append(out, it)
 		}
 	}
 	if len(out) == 0 {
-		return tsUnknown
+		return unknownType()
 	}
 	if len(out) == 1 {
 		return out[0]
 	}
-	return "(" + strings.Join(out, " | ") + ")"
+	return unionOf(out...)
 }
 
-func unionKeyTypes(items []string) string {
+func unionKeyTypes(items []*TypeNode) *TypeNode {
 	seen := map[string]bool{}
-	out := make([]string, 0, len(items))
+	out := make([]*TypeNode, 0, len(items))
 	for _, it := range items {
-		if it == "" {
+		if it == nil {
 			continue
 		}
-		if it == schemaTypeString {
-			return schemaTypeString
+		if isPrimitive(it, schemaTypeString) {
+			return stringType()
 		}
-		if !seen[it] {
-			seen[it] = true
+		if key := typeKey(it); !seen[key] {
+			seen[key] = true
 			out = append(out, it)
 		}
 	}
 	if len(out) == 0 {
-		return ""
+		return nil
 	}
 	if len(out) == 1 {
 		return out[0]
 	}
-	return "(" + strings.Join(out, " | ") + ")"
+	return unionOf(out...)
 }
 
-func patternKeyType(pattern string) string {
+func patternKeyType(pattern string) *TypeNode {
 	if pattern == "^[0-9]+$" || pattern == "^\\d+$" {
-		return "`${number}`"
+		return templateType("", primitiveType("number"))
 	}
 	if !strings.HasPrefix(pattern, "^") {
-		return schemaTypeString
+		return stringType()
 	}
 	p := strings.TrimPrefix(pattern, "^")
 	exact := false
@@ -1916,15 +2253,15 @@ func patternKeyType(pattern string) string {
 		p = strings.TrimSuffix(p, ".+")
 	}
 	if p == "" || strings.Contains(p, "`") {
-		return schemaTypeString
+		return stringType()
 	}
 	if strings.ContainsAny(p, "[]()|+*?.\\") {
-		return schemaTypeString
+		return stringType()
 	}
 	if exact {
-		return strconv.Quote(p)
+		return literalType(p)
 	}
-	return "`" + p + "${string}`"
+	return templateType(p, stringType())
 }
 
 func anySlice(v any) []any {
@@ -1949,26 +2286,6 @@ func safeProp(k string) string {
 	return strconv.Quote(k)
 }
 
-func componentSchemaRef(name string) string {
-	return "Components[\"schemas\"][" + strconv.Quote(name) + "]"
-}
-
-func componentResponseRef(name string) string {
-	return "Components[\"responses\"][" + strconv.Quote(name) + "]"
-}
-
-func componentRequestBodyRef(name string) string {
-	return "Components[\"requestBodies\"][" + strconv.Quote(name) + "]"
-}
-
-func componentParameterRef(name string) string {
-	return "Components[\"parameters\"][" + strconv.Quote(name) + "]"
-}
-
-func componentHeaderRef(name string) string {
-	return "Components[\"headers\"][" + strconv.Quote(name) + "]"
-}
-
 func isIdent(s string) bool {
 	if s == "" {
 		return false
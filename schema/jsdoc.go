@@ -0,0 +1,201 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+var schemaDocConstraints = []string{
+	"format",
+	"minimum",
+	"exclusiveMinimum",
+	"maximum",
+	"exclusiveMaximum",
+	"multipleOf",
+	"minLength",
+	"maxLength",
+	"pattern",
+	"minItems",
+	"maxItems",
+	"uniqueItems",
+	"minProperties",
+	"maxProperties",
+	"default",
+}
+
+func schemaDoc(o map[string]any) []string {
+	if o == nil {
+		return nil
+	}
+	tags := []string{}
+	if d, ok := o["description"].(string); ok {
+		tags = appendDocText(tags, "@description", d)
+	}
+	if v, ok := o["deprecated"].(bool); ok && v {
+		tags = append(tags, "@deprecated")
+	}
+	if ex, ok := o["example"]; ok {
+		tags = appendDocValue(tags, "@example", ex)
+	}
+	if ed, ok := o["externalDocs"].(map[string]any); ok {
+		url, _ := ed["url"].(string)
+		desc, _ := ed["description"].(string)
+		tags = appendDocSee(tags, url, desc)
+	}
+	for _, k := range schemaDocConstraints {
+		v, ok := o[k]
+		if !ok {
+			continue
+		}
+		if k == "default" {
+			tags = appendDocJSON(tags, "@"+k, v)
+			continue
+		}
+		tags = appendDocValue(tags, "@"+k, v)
+	}
+	return tags
+}
+
+func componentSchemaDoc(s *Schema) []string {
+	if s == nil {
+		return nil
+	}
+	o := make(map[string]any, len(s.Other)+3)
+	for k, v := range s.Other {
+		o[k] = v
+	}
+	if s.Deprecated {
+		o["deprecated"] = true
+	}
+	if s.Example != nil {
+		o["example"] = s.Example
+	}
+	if s.ExternalDocs != nil {
+		o["externalDocs"] = map[string]any{"url": s.ExternalDocs.URL, "description": s.ExternalDocs.Description}
+	}
+	return schemaDoc(o)
+}
+
+func refOrSchemaDoc(s *RefOr[Schema]) []string {
+	if s == nil || s.Value == nil {
+		return nil
+	}
+	return componentSchemaDoc(s.Value)
+}
+
+func parameterDoc(p *Parameter) []string {
+	if p == nil {
+		return nil
+	}
+	tags := appendDocText(nil, "@description", p.Description)
+	if p.Deprecated {
+		tags = append(tags, "@deprecated")
+	}
+	if p.Example != nil {
+		tags = appendDocValue(tags, "@example", p.Example)
+	}
+	return mergeSchemaDoc(tags, refOrSchemaDoc(p.Schema))
+}
+
+func headerDoc(h *Header) []string {
+	if h == nil {
+		return nil
+	}
+	tags := appendDocText(nil, "@description", h.Description)
+	if h.Deprecated {
+		tags = append(tags, "@deprecated")
+	}
+	if h.Example != nil {
+		tags = appendDocValue(tags, "@example", h.Example)
+	}
+	return mergeSchemaDoc(tags, refOrSchemaDoc(h.Schema))
+}
+
+func operationDoc(op *Operation) []string {
+	if op == nil {
+		return nil
+	}
+	tags := appendDocText(nil, "@summary", op.Summary)
+	tags = appendDocText(tags, "@description", op.Description)
+	if op.Deprecated {
+		tags = append(tags, "@deprecated")
+	}
+	if op.ExternalDocs != nil {
+		tags = appendDocSee(tags, op.ExternalDocs.URL, op.ExternalDocs.Description)
+	}
+	return tags
+}
+
+func mergeSchemaDoc(tags, schemaTags []string) []string {
+	seen := map[string]bool{}
+	for _, t := range tags {
+		seen[docTagName(t)] = true
+	}
+	for _, t := range schemaTags {
+		if !seen[docTagName(t)] {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+func docTagName(tag string) string {
+	name, _, _ := strings.Cut(tag, " ")
+	return name
+}
+
+func appendDocText(tags []string, tag, text string) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return tags
+	}
+	return append(tags, tag+" "+escapeDoc(text))
+}
+
+func appendDocValue(tags []string, tag string, v any) []string {
+	if s, ok := v.(string); ok {
+		return appendDocText(tags, tag, s)
+	}
+	return appendDocJSON(tags, tag, v)
+}
+
+func appendDocJSON(tags []string, tag string, v any) []string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return tags
+	}
+	return append(tags, tag+" "+escapeDoc(string(data)))
+}
+
+func appendDocSee(tags []string, url, desc string) []string {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return tags
+	}
+	if desc = strings.TrimSpace(desc); desc != "" {
+		return append(tags, "@see "+escapeDoc(url+" "+desc))
+	}
+	return append(tags, "@see "+escapeDoc(url))
+}
+
+func escapeDoc(s string) string {
+	return strings.ReplaceAll(s, "*/", "*\\/")
+}
+
+func jsDoc(indent string, tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	if len(tags) == 1 && !strings.Contains(tags[0], "\n") {
+		return indent + "/** " + tags[0] + " */\n"
+	}
+	var b strings.Builder
+	b.WriteString(indent + "/**\n")
+	for _, t := range tags {
+		for _, line := range strings.Split(t, "\n") {
+			b.WriteString(strings.TrimRight(indent+" * "+line, " ") + "\n")
+		}
+	}
+	b.WriteString(indent + " */\n")
+	return b.String()
+}
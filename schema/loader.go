@@ -0,0 +1,800 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	ErrRefCycle          = errors.New("$ref cycle")
+	ErrRefTargetNotFound = errors.New("$ref target not found")
+	ErrRemoteRef         = errors.New("remote $ref is not supported")
+	ErrInvalidJSON       = errors.New("invalid JSON")
+)
+
+type nodeKind int
+
+var mapItemKinds = map[nodeKind]nodeKind{
+	kindPathMap:           kindPathItem,
+	kindSchemaMap:         kindSchema,
+	kindResponseMap:       kindResponse,
+	kindParameterMap:      kindParameter,
+	kindRequestBodyMap:    kindRequestBody,
+	kindHeaderMap:         kindHeader,
+	kindSecuritySchemeMap: kindSecurityScheme,
+	kindExampleMap:        kindExample,
+	kindLinkMap:           kindLink,
+	kindCallbackMap:       kindCallback,
+	kindContentMap:        kindMediaType,
+	kindEncodingMap:       kindEncoding,
+	kindCallback:          kindPathItem,
+}
+
+var kindSections = map[nodeKind]string{
+	kindSchema:         "schemas",
+	kindResponse:       "responses",
+	kindParameter:      "parameters",
+	kindRequestBody:    "requestBodies",
+	kindHeader:         "headers",
+	kindSecurityScheme: "securitySchemes",
+	kindExample:        "examples",
+	kindLink:           "links",
+	kindCallback:       "callbacks",
+}
+
+type loader struct {
+	fsys           fs.FS
+	files          map[string]*yaml.Node
+	origins        map[*yaml.Node]string
+	hoisted        map[string]string
+	names          map[string]map[string]bool
+	pending        map[string][]*yaml.Node
+	inlining       map[string]bool
+	root           string
+	data           []byte
+	sections       []string
+	refErrs        []refError
+	collectRefErrs bool
+}
+
+type refError struct {
+	err  error
+	node *yaml.Node
+	file string
+}
+
+func LoadDocument(file string, format InputFormat) (*Document, error) {
+	doc, _, err := loadDocument(file, format)
+	return doc, err
+}
+
+func SchemaFiles(file string, format InputFormat) ([]string, error) {
+	_, l, err := loadDocument(file, format)
+	if l == nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(l.files))
+	for f := range l.files {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files, err
+}
+
+func loadDocument(file string, format InputFormat) (*Document, *loader, error) {
+	return loadDocumentWith(file, format, false)
+}
+
+func loadDocumentWith(file string, format InputFormat, collectRefErrs bool) (*Document, *loader, error) {
+	if file == StdioPath {
+		return nil, nil, ErrStdinPath
+	}
+
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolve schema file %q: %w", file, err)
+	}
+	l := newLoader(abs, nil, nil)
+	l.collectRefErrs = collectRefErrs
+	doc, err := l.load(file, format)
+	return doc, l, err
+}
+
+func loadDocumentData(data []byte, baseDir string, format InputFormat) (*Document, *loader, error) {
+	l, err := dataLoader(data, baseDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, err := l.load(stdinName, format)
+	return doc, l, err
+}
+
+func dataLoader(data []byte, baseDir string) (*loader, error) {
+	root, err := filepath.Abs(filepath.Join(baseDir, stdinName))
+	if err != nil {
+		return nil, fmt.Errorf("resolve base dir %q: %w", baseDir, err)
+	}
+	return newLoader(root, nil, data), nil
+}
+
+func loadDocumentFS(fsys fs.FS, name string, format InputFormat) (*Document, *loader, error) {
+	l := newLoader(path.Clean(name), fsys, nil)
+	doc, err := l.load(name, format)
+	return doc, l, err
+}
+
+func newLoader(root string, fsys fs.FS, data []byte) *loader {
+	return &loader{
+		fsys:     fsys,
+		files:    map[string]*yaml.Node{},
+		origins:  map[*yaml.Node]string{},
+		hoisted:  map[string]string{},
+		names:    map[string]map[string]bool{},
+		pending:  map[string][]*yaml.Node{},
+		inlining: map[string]bool{},
+		root:     root,
+		data:     data,
+	}
+}
+
+func (l *loader) load(name string, format InputFormat) (*Document, error) {
+	root, err := l.loadFile(l.root, format)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc Document
+	if root == nil {
+		return &doc, nil
+	}
+
+	swagger, err := isSwagger2(root)
+	if err != nil {
+		return nil, fmt.Errorf("load schema %q: %w", name, err)
+	}
+	if swagger {
+		root = convertSwagger2(root)
+		l.files[l.root] = root
+	}
+
+	l.reserveComponentNames(root)
+	if err := l.walk(root, l.root, kindDocument); err != nil {
+		return nil, fmt.Errorf("resolve refs in %q: %w", name, err)
+	}
+	l.attachHoisted(root)
+
+	if err := root.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unmarshal schema %q: %w", name, err)
+	}
+	doc.source = root
+	return &doc, nil
+}
+
+func (l *loader) loadFile(file string, format InputFormat) (*yaml.Node, error) {
+	if n, ok := l.files[file]; ok {
+		return n, nil
+	}
+
+	data, err := l.readFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read schema %q: %w", file, err)
+	}
+
+	if format == InputAuto {
+		format = detectFormat(file, data)
+	}
+
+	var n *yaml.Node
+	switch format {
+	case InputJSON:
+		n, err = decodeJSONNode(data)
+	default:
+		n, err = decodeYAMLNode(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal schema %q: %w", file, err)
+	}
+
+	l.files[file] = n
+	return n, nil
+}
+
+func (l *loader) readFile(name string) ([]byte, error) {
+	if l.data != nil && name == l.root {
+		return l.data, nil
+	}
+	if l.fsys != nil {
+		return fs.ReadFile(l.fsys, name)
+	}
+	return os.ReadFile(name)
+}
+
+func decodeYAMLNode(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, nil
+	}
+	return doc.Content[0], nil
+}
+
+func decodeJSONNode(data []byte) (*yaml.Node, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	lines := newLineIndex(data)
+	n, err := decodeJSONValue(dec, data, lines)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		line, col := lines.position(int(dec.InputOffset()))
+		return nil, fmt.Errorf("%w: unexpected data after top-level value at %d:%d", ErrInvalidJSON, line, col)
+	}
+	return n, nil
+}
+
+func decodeJSONValue(dec *json.Decoder, data []byte, lines lineIndex) (*yaml.Node, error) {
+	line, col := lines.position(skipJSONSeparators(data, int(dec.InputOffset())))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := tok.(type) {
+	case json.Delim:
+		switch v {
+		case '{':
+			n := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: line, Column: col}
+			for dec.More() {
+				keyLine, keyCol := lines.position(skipJSONSeparators(data, int(dec.InputOffset())))
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, ok := keyTok.(string)
+				if !ok {
+					return nil, fmt.Errorf("%w: object key at %d:%d", ErrInvalidJSON, keyLine, keyCol)
+				}
+				val, err := decodeJSONValue(dec, data, lines)
+				if err != nil {
+					return nil, err
+				}
+				n.Content = append(n.Content, stringNode(key, keyLine, keyCol), val)
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return n, nil
+		case '[':
+			n := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Line: line, Column: col}
+			for dec.More() {
+				val, err := decodeJSONValue(dec, data, lines)
+				if err != nil {
+					return nil, err
+				}
+				n.Content = append(n.Content, val)
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return n, nil
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q at %d:%d", ErrInvalidJSON, v.String(), line, col)
+		}
+	case string:
+		return stringNode(v, line, col), nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(v.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String(), Line: line, Column: col}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v), Line: line, Column: col}, nil
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null", Line: line, Column: col}, nil
+	}
+}
+
+func stringNode(v string, line, col int) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Style: yaml.DoubleQuotedStyle, Value: v, Line: line, Column: col}
+}
+
+func skipJSONSeparators(data []byte, offset int) int {
+	for offset < len(data) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+type lineIndex []int
+
+func newLineIndex(data []byte) lineIndex {
+	starts := lineIndex{0}
+	for i, c := range data {
+		if c == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+func (idx lineIndex) position(offset int) (line, col int) {
+	lo, hi := 0, len(idx)-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if idx[mid] <= offset {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo + 1, offset - idx[lo] + 1
+}
+
+func (l *loader) reserveComponentNames(root *yaml.Node) {
+	components := mappingValue(root, "components")
+	if components == nil || components.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(components.Content); i += 2 {
+		section := components.Content[i].Value
+		values := components.Content[i+1]
+		if values.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(values.Content); j += 2 {
+			l.usedNames(section)[values.Content[j].Value] = true
+		}
+	}
+}
+
+func (l *loader) usedNames(section string) map[string]bool {
+	used, ok := l.names[section]
+	if !ok {
+		used = map[string]bool{}
+		l.names[section] = used
+	}
+	return used
+}
+
+func (l *loader) walk(n *yaml.Node, file string, kind nodeKind) error {
+	if n == nil || kind == kindLiteral {
+		return nil
+	}
+	switch n.Kind {
+	case yaml.SequenceNode:
+		elem := sequenceItemKind(kind)
+		for _, c := range n.Content {
+			if err := l.walk(c, file, elem); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		if _, isMap := mapItemKinds[kind]; !isMap || kind == kindCallback {
+			if ref := mappingValue(n, "$ref"); ref != nil && ref.Kind == yaml.ScalarNode {
+				err := l.resolveRef(n, ref, file, kind)
+				if err != nil && l.collectRefErrs {
+					l.refErrs = append(l.refErrs, refError{err: err, node: ref, file: file})
+					return nil
+				}
+				return err
+			}
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if err := l.walk(n.Content[i+1], file, childKind(kind, n.Content[i].Value)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (l *loader) resolveRef(n, ref *yaml.Node, file string, kind nodeKind) error {
+	if strings.HasPrefix(ref.Value, "#") && file == l.root {
+		return nil
+	}
+
+	targetFile, pointer, target, err := l.followRef(file, ref.Value)
+	if err != nil {
+		return err
+	}
+
+	if targetFile == l.root {
+		ref.Value = "#" + pointer
+		return nil
+	}
+
+	if section, ok := kindSections[kind]; ok {
+		name, err := l.hoist(section, targetFile, pointer, target, kind)
+		if err != nil {
+			return err
+		}
+		ref.Value = "#/components/" + section + "/" + escapePointerToken(name)
+		return nil
+	}
+
+	origin := targetFile + "#" + pointer
+	if l.inlining[origin] {
+		return fmt.Errorf("%w: %s", ErrRefCycle, origin)
+	}
+	l.inlining[origin] = true
+	defer delete(l.inlining, origin)
+
+	cp := copyNode(target)
+	if err := l.walk(cp, targetFile, kind); err != nil {
+		return err
+	}
+	*n = *cp
+	l.origins[n] = targetFile
+	return nil
+}
+
+func (l *loader) followRef(file, ref string) (targetFile, pointer string, target *yaml.Node, err error) {
+	seen := map[string]bool{}
+	for {
+		targetFile, pointer, err = l.refLocation(file, ref)
+		if err != nil {
+			return "", "", nil, err
+		}
+		origin := targetFile + "#" + pointer
+		if seen[origin] {
+			return "", "", nil, fmt.Errorf("%w: %s", ErrRefCycle, origin)
+		}
+		seen[origin] = true
+
+		if targetFile == l.root {
+			return targetFile, pointer, nil, nil
+		}
+
+		doc, err := l.loadFile(targetFile, InputAuto)
+		if err != nil {
+			return "", "", nil, err
+		}
+		target, err = resolvePointer(doc, pointer)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("%s: %w", origin, err)
+		}
+
+		next := mappingValue(target, "$ref")
+		if target.Kind != yaml.MappingNode || next == nil || next.Kind != yaml.ScalarNode {
+			return targetFile, pointer, target, nil
+		}
+		file, ref = targetFile, next.Value
+	}
+}
+
+func (l *loader) hoist(section, file, pointer string, target *yaml.Node, kind nodeKind) (string, error) {
+	origin := section + ":" + file + "#" + pointer
+	if name, ok := l.hoisted[origin]; ok {
+		return name, nil
+	}
+
+	base := refBaseName(file, pointer)
+	used := l.usedNames(section)
+	name := base
+	for i := 2; used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	used[name] = true
+	l.hoisted[origin] = name
+
+	cp := copyNode(target)
+	l.origins[cp] = file
+	if err := l.walk(cp, file, kind); err != nil {
+		return "", err
+	}
+
+	if _, ok := l.pending[section]; !ok {
+		l.sections = append(l.sections, section)
+	}
+	l.pending[section] = append(l.pending[section], stringNode(name, 0, 0), cp)
+	return name, nil
+}
+
+func (l *loader) attachHoisted(root *yaml.Node) {
+	if len(l.sections) == 0 {
+		return
+	}
+	components := ensureMapping(root, "components")
+	for _, section := range l.sections {
+		values := ensureMapping(components, section)
+		values.Content = append(values.Content, l.pending[section]...)
+	}
+}
+
+func ensureMapping(n *yaml.Node, key string) *yaml.Node {
+	if v := mappingValue(n, key); v != nil && v.Kind == yaml.MappingNode {
+		return v
+	}
+	v := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			n.Content[i+1] = v
+			return v
+		}
+	}
+	n.Content = append(n.Content, stringNode(key, 0, 0), v)
+	return v
+}
+
+func (l *loader) refLocation(file, ref string) (targetFile, pointer string, err error) {
+	target, fragment, _ := strings.Cut(ref, "#")
+	if strings.Contains(target, "://") {
+		return "", "", fmt.Errorf("%w: %q", ErrRemoteRef, ref)
+	}
+	if fragment != "" {
+		if fragment, err = url.PathUnescape(fragment); err != nil {
+			return "", "", fmt.Errorf("%w: %q", ErrUnsupportedRef, ref)
+		}
+	}
+	if target == "" {
+		return file, fragment, nil
+	}
+	if target, err = url.PathUnescape(target); err != nil {
+		return "", "", fmt.Errorf("%w: %q", ErrUnsupportedRef, ref)
+	}
+	if l.fsys != nil {
+		if !strings.HasPrefix(target, "/") {
+			target = path.Join(path.Dir(file), target)
+		}
+		return path.Clean(strings.TrimPrefix(target, "/")), fragment, nil
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(file), filepath.FromSlash(target))
+	}
+	return filepath.Clean(target), fragment, nil
+}
+
+func resolvePointer(doc *yaml.Node, pointer string) (*yaml.Node, error) {
+	n := doc
+	if n == nil {
+		return nil, fmt.Errorf("%w: empty document", ErrRefTargetNotFound)
+	}
+	if pointer == "" || pointer == "/" {
+		return n, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedRef, pointer)
+	}
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = unescapePointerToken(token)
+		for n.Kind == yaml.AliasNode && n.Alias != nil {
+			n = n.Alias
+		}
+		var next *yaml.Node
+		switch n.Kind {
+		case yaml.MappingNode:
+			next = mappingValue(n, token)
+		case yaml.SequenceNode:
+			if i, err := strconv.Atoi(token); err == nil && i >= 0 && i < len(n.Content) {
+				next = n.Content[i]
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("%w: %q", ErrRefTargetNotFound, pointer)
+		}
+		n = next
+	}
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n, nil
+}
+
+func unescapePointerToken(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+}
+
+func escapePointerToken(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func refBaseName(file, pointer string) string {
+	tokens := strings.Split(strings.Trim(pointer, "/"), "/")
+	if last := unescapePointerToken(tokens[len(tokens)-1]); last != "" {
+		return last
+	}
+	base := filepath.Base(file)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+func detectFormat(file string, data []byte) InputFormat {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		return InputJSON
+	case ".yml", ".yaml":
+		return InputYAML
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return InputJSON
+	}
+	return InputYAML
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func copyNode(n *yaml.Node) *yaml.Node {
+	if n == nil {
+		return nil
+	}
+	cp := *n
+	if len(n.Content) > 0 {
+		cp.Content = make([]*yaml.Node, len(n.Content))
+		for i, c := range n.Content {
+			cp.Content[i] = copyNode(c)
+		}
+	}
+	return &cp
+}
+
+func sequenceItemKind(kind nodeKind) nodeKind {
+	switch kind {
+	case kindSchema, kindSchemaList:
+		return kindSchema
+	case kindParameterList:
+		return kindParameter
+	default:
+		return kindOther
+	}
+}
+
+func childKind(kind nodeKind, key string) nodeKind {
+	if item, ok := mapItemKinds[kind]; ok {
+		return item
+	}
+	switch kind {
+	case kindDocument:
+		return documentChildKind(key)
+	case kindComponents:
+		return componentsChildKind(key)
+	case kindPathItem:
+		switch key {
+		case "parameters":
+			return kindParameterList
+		case "get", "put", "post", "delete", "options", "head", "patch", "trace":
+			return kindOperation
+		}
+	case kindOperation:
+		switch key {
+		case "parameters":
+			return kindParameterList
+		case "requestBody":
+			return kindRequestBody
+		case "responses":
+			return kindResponseMap
+		case "callbacks":
+			return kindCallbackMap
+		}
+	case kindParameter, kindHeader:
+		switch key {
+		case "schema":
+			return kindSchema
+		case "content":
+			return kindContentMap
+		case "examples":
+			return kindExampleMap
+		case "example":
+			return kindLiteral
+		}
+	case kindRequestBody:
+		if key == "content" {
+			return kindContentMap
+		}
+	case kindResponse:
+		switch key {
+		case "headers":
+			return kindHeaderMap
+		case "content":
+			return kindContentMap
+		case "links":
+			return kindLinkMap
+		}
+	case kindMediaType:
+		switch key {
+		case "schema":
+			return kindSchema
+		case "examples":
+			return kindExampleMap
+		case "encoding":
+			return kindEncodingMap
+		case "example":
+			return kindLiteral
+		}
+	case kindEncoding:
+		if key == "headers" {
+			return kindHeaderMap
+		}
+	case kindExample:
+		if key == "value" {
+			return kindLiteral
+		}
+	case kindSchema:
+		return schemaChildKind(key)
+	}
+	return kindOther
+}
+
+func documentChildKind(key string) nodeKind {
+	switch key {
+	case "paths", "webhooks":
+		return kindPathMap
+	case "components":
+		return kindComponents
+	}
+	return kindOther
+}
+
+func componentsChildKind(key string) nodeKind {
+	switch key {
+	case "schemas":
+		return kindSchemaMap
+	case "responses":
+		return kindResponseMap
+	case "parameters":
+		return kindParameterMap
+	case "requestBodies":
+		return kindRequestBodyMap
+	case "headers":
+		return kindHeaderMap
+	case "securitySchemes":
+		return kindSecuritySchemeMap
+	case "examples":
+		return kindExampleMap
+	case "links":
+		return kindLinkMap
+	case "callbacks":
+		return kindCallbackMap
+	case "pathItems":
+		return kindPathMap
+	}
+	return kindOther
+}
+
+func schemaChildKind(key string) nodeKind {
+	switch key {
+	case "properties", "patternProperties", "$defs", "definitions", "dependentSchemas":
+		return kindSchemaMap
+	case "allOf", "anyOf", "oneOf", "prefixItems":
+		return kindSchemaList
+	case "items", "additionalProperties", "additionalItems", "not", "if", "then", "else",
+		"contains", "propertyNames", "unevaluatedItems", "unevaluatedProperties", "contentSchema":
+		return kindSchema
+	case "example", "examples", "default", "enum", "const":
+		return kindLiteral
+	}
+	return kindOther
+}
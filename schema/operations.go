@@ -0,0 +1,109 @@
+package schema
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var operationAliasSuffixes = []string{"Params", "Query", "Headers", "Cookies", "Body", "Response"}
+
+type IROperationName struct {
+	Name   string
+	Path   string
+	Method string
+}
+
+func assignOperationNames(paths map[string]IRPathItem) []IROperationName {
+	var explicit, generated []IROperationName
+	for _, path := range sortedPathKeys(paths) {
+		ops := paths[path].Ops
+		for _, method := range sortedOpKeys(ops) {
+			entry := IROperationName{Path: path, Method: method, Name: ops[method].OperationID}
+			if entry.Name == "" {
+				entry.Name = operationNameFromRoute(method, path)
+				generated = append(generated, entry)
+				continue
+			}
+			explicit = append(explicit, entry)
+		}
+	}
+
+	names := map[string]bool{}
+	out := make([]IROperationName, 0, len(explicit)+len(generated))
+	for _, entry := range append(explicit, generated...) {
+		base := entry.Name
+		for i := 2; names[entry.Name]; i++ {
+			entry.Name = base + strconv.Itoa(i)
+		}
+		names[entry.Name] = true
+		out = append(out, entry)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+func reserveOperationAlias(base string, used map[string]bool) string {
+	alias := base
+	for i := 2; operationAliasTaken(alias, used); i++ {
+		alias = base + strconv.Itoa(i)
+	}
+	used[alias] = true
+	for _, suffix := range operationAliasSuffixes {
+		used[alias+suffix] = true
+	}
+	return alias
+}
+
+func operationAliasTaken(alias string, used map[string]bool) bool {
+	if used[alias] {
+		return true
+	}
+	for _, suffix := range operationAliasSuffixes {
+		if used[alias+suffix] {
+			return true
+		}
+	}
+	return false
+}
+
+func operationNameFromRoute(method, path string) string {
+	var b strings.Builder
+	b.WriteString(method)
+	for _, seg := range strings.Split(path, "/") {
+		if seg == "" {
+			continue
+		}
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			b.WriteString("By" + pascalIdent(strings.Trim(seg, "{}")))
+			continue
+		}
+		b.WriteString(pascalIdent(seg))
+	}
+	return b.String()
+}
+
+func pascalIdent(s string) string {
+	return prefixLeadingDigit(pascalWords(s), "Op")
+}
+
+func pascalWords(s string) string {
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+	var b strings.Builder
+	for _, p := range parts {
+		b.WriteString(strings.ToUpper(p[:1]) + p[1:])
+	}
+	return b.String()
+}
+
+func prefixLeadingDigit(s, prefix string) string {
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		return prefix + s
+	}
+	return s
+}
@@ -0,0 +1,140 @@
+package schema
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+type keyOrder struct {
+	root *yaml.Node
+}
+
+func newKeyOrder(root *yaml.Node) *keyOrder {
+	if root == nil {
+		return nil
+	}
+	return &keyOrder{root: root}
+}
+
+func (o *keyOrder) node(tokens []string) *yaml.Node {
+	if o == nil {
+		return nil
+	}
+	n := o.root
+	for _, tok := range tokens {
+		n = o.deref(n)
+		switch n.Kind {
+		case yaml.MappingNode:
+			n = mappingValue(n, tok)
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(n.Content) {
+				return nil
+			}
+			n = n.Content[i]
+		default:
+			return nil
+		}
+		if n == nil {
+			return nil
+		}
+	}
+	return o.deref(n)
+}
+
+func (o *keyOrder) deref(n *yaml.Node) *yaml.Node {
+	for range maxSchemaDepth {
+		n = derefAlias(n)
+		ref := mappingValue(n, "$ref")
+		if ref == nil || !strings.HasPrefix(ref.Value, "#") {
+			return n
+		}
+		target, err := resolvePointer(o.root, ref.Value[1:])
+		if err != nil {
+			return n
+		}
+		n = target
+	}
+	return n
+}
+
+func (o *keyOrder) keys(tokens ...string) []string {
+	n := o.node(tokens)
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	out := make([]string, 0, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		out = append(out, n.Content[i].Value)
+	}
+	return out
+}
+
+func (o *keyOrder) params(item []string, method string) []string {
+	var out []string
+	shared := append(item[:len(item):len(item)], "parameters")
+	own := append(item[:len(item):len(item)], method, "parameters")
+	for _, tokens := range [][]string{shared, own} {
+		n := o.node(tokens)
+		if n == nil || n.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, p := range n.Content {
+			p = o.deref(p)
+			if name := mappingValue(p, "name"); name != nil {
+				out = append(out, name.Value)
+			}
+		}
+	}
+	return out
+}
+
+func derefAlias(n *yaml.Node) *yaml.Node {
+	for n != nil && n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
+
+func orderKeys(keys, declared []string, less func(a, b string) bool) {
+	if less == nil {
+		less = func(a, b string) bool { return a < b }
+	}
+	pos := make(map[string]int, len(declared))
+	for i, k := range declared {
+		if _, ok := pos[k]; !ok {
+			pos[k] = i
+		}
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		pi, iok := pos[keys[i]]
+		pj, jok := pos[keys[j]]
+		if iok && jok {
+			return pi < pj
+		}
+		if iok != jok {
+			return iok
+		}
+		return less(keys[i], keys[j])
+	})
+}
+
+func (c *enumContext) orderFields(fields []Field) {
+	if c == nil || c.order == nil || c.diags == nil {
+		return
+	}
+	tokens := append(append([]string(nil), c.diags.path...), "properties")
+	names := make([]string, len(fields))
+	byName := make(map[string]Field, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+		byName[f.Name] = f
+	}
+	orderKeys(names, c.order.keys(tokens...), nil)
+	for i, name := range names {
+		fields[i] = byName[name]
+	}
+}
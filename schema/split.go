@@ -0,0 +1,286 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var ErrUnknownSplitMode = errors.New("unknown split mode")
+
+type SplitMode string
+
+const (
+	SplitNone    SplitMode = ""
+	SplitSection SplitMode = "section"
+	SplitTag     SplitMode = "tag"
+)
+
+const (
+	enumsModule      = "enums"
+	componentsModule = "components"
+	routesModule     = "routes"
+	webhooksModule   = "webhooks"
+	indexModule      = "index"
+	untaggedTag      = "default"
+	moduleExt        = ".ts"
+)
+
+func ParseSplitMode(s string) (SplitMode, error) {
+	switch mode := SplitMode(s); mode {
+	case SplitNone, SplitSection, SplitTag:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownSplitMode, s)
+	}
+}
+
+type tsModule struct {
+	imports map[string]map[string]bool
+	name    string
+	body    strings.Builder
+}
+
+type tagGroup struct {
+	paths   map[string]IRPathItem
+	module  string
+	routes  string
+	opsName string
+	ops     []IROperationName
+	aliases []string
+}
+
+func newTSModule(name string) *tsModule {
+	return &tsModule{name: name, imports: map[string]map[string]bool{}}
+}
+
+func (m *tsModule) use(from, name string) {
+	if from == m.name {
+		return
+	}
+	if m.imports[from] == nil {
+		m.imports[from] = map[string]bool{}
+	}
+	m.imports[from][name] = true
+}
+
+func (m *tsModule) useType(t *TypeNode) {
+	if t == nil {
+		return
+	}
+	switch t.Kind {
+	case TypeEnum, TypeBrand:
+		m.use(enumsModule, t.Name)
+	case TypeRef:
+		m.use(componentsModule, "Components")
+	}
+	m.useType(t.Elem)
+	m.useType(t.Key)
+	for _, it := range t.Items {
+		m.useType(it)
+	}
+	for _, f := range t.Fields {
+		m.useType(f.Type)
+	}
+}
+
+func (m *tsModule) usePathItems(items map[string]IRPathItem) {
+	for _, item := range items {
+		for _, op := range item.Ops {
+			for _, params := range []map[string]IRParam{op.PathParams, op.QueryParams, op.HeaderParams, op.CookieParams} {
+				for _, p := range params {
+					m.useType(p.Type)
+				}
+			}
+			m.useType(op.RequestBody)
+			for _, r := range op.Responses {
+				m.useType(r)
+			}
+			for _, cb := range op.Callbacks {
+				m.usePathItems(cb)
+			}
+		}
+	}
+}
+
+func (m *tsModule) render(header string) string {
+	var b strings.Builder
+	b.WriteString(header)
+	from := make([]string, 0, len(m.imports))
+	for k := range m.imports {
+		from = append(from, k)
+	}
+	sort.Strings(from)
+	for _, f := range from {
+		names := make([]string, 0, len(m.imports[f]))
+		for n := range m.imports[f] {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		b.WriteString("import type { " + strings.Join(names, ", ") + " } from " + strconv.Quote("./"+f) + ";\n")
+	}
+	if len(from) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString(m.body.String())
+	return b.String()
+}
+
+func EmitTypeFilesFromIR(ir *IR, generatedAt time.Time, cliVersion, openAPIVersion string, opts EmitOptions, mode SplitMode) map[string]string {
+	header := GeneratedHeader(generatorName(cliVersion), openAPIVersion, generatedAt)
+	names := reservedNames(ir)
+
+	enums := newTSModule(enumsModule)
+	writeEnums(&enums.body, ir, opts.EnumStyle)
+	writeBrands(&enums.body, ir)
+
+	components := newTSModule(componentsModule)
+	writeServers(&components.body, ir)
+	writeComponents(&components.body, ir)
+	if opts.ComponentAliases {
+		writeComponentAliases(&components.body, ir, opts, names)
+	}
+	for _, values := range []map[string]*TypeNode{
+		ir.ComponentsSchemas,
+		ir.ComponentsResponses,
+		ir.ComponentsRequestBody,
+		ir.ComponentsParameters,
+		ir.ComponentsHeaders,
+		ir.ComponentsSecuritySchemes,
+	} {
+		for _, t := range values {
+			components.useType(t)
+		}
+	}
+
+	modules := []*tsModule{enums, components}
+	aliases := operationAliases(ir, opts, names)
+	routes := newTSModule(routesModule)
+	if mode == SplitTag {
+		groups := groupOperationsByTag(ir, aliases, names)
+		labels := map[string][]string{}
+		for _, g := range groups {
+			m := newTSModule(g.module)
+			writePathItems(&m.body, g.routes, "paths", g.paths, ir.order)
+			writeOperations(&m.body, g.opsName, g.routes, g.ops, g.aliases, ir.Paths)
+			m.usePathItems(g.paths)
+			modules = append(modules, m)
+
+			routes.use(g.module, g.routes)
+			labels["Routes"] = append(labels["Routes"], g.routes)
+			if len(g.ops) > 0 {
+				routes.use(g.module, g.opsName)
+				labels["Operations"] = append(labels["Operations"], g.opsName)
+			}
+		}
+		for _, label := range []string{"Routes", "Operations"} {
+			if len(labels[label]) > 0 {
+				routes.body.WriteString("export type " + label + " = " + strings.Join(labels[label], " & ") + ";\n\n")
+			}
+		}
+	} else {
+		writeRoutes(&routes.body, ir)
+		writeOperations(&routes.body, "Operations", "Routes", ir.Operations, aliases, ir.Paths)
+		routes.usePathItems(ir.Paths)
+	}
+	modules = append(modules, routes)
+
+	webhooks := newTSModule(webhooksModule)
+	writeWebhooks(&webhooks.body, ir)
+	webhooks.usePathItems(ir.Webhooks)
+	modules = append(modules, webhooks)
+
+	files := map[string]string{}
+	var index strings.Builder
+	index.WriteString(header)
+	for _, m := range modules {
+		if m.body.Len() == 0 {
+			continue
+		}
+		files[m.name+moduleExt] = normalizeGeneratedOutput(m.render(header))
+		index.WriteString("export * from " + strconv.Quote("./"+m.name) + ";\n")
+	}
+	files[indexModule+moduleExt] = normalizeGeneratedOutput(index.String())
+	return files
+}
+
+func isSplitModule(file string) bool {
+	name, ok := strings.CutSuffix(file, moduleExt)
+	if !ok {
+		return false
+	}
+	switch name {
+	case enumsModule, componentsModule, routesModule, webhooksModule, indexModule:
+		return true
+	}
+	return strings.HasSuffix(name, "."+routesModule)
+}
+
+func groupOperationsByTag(ir *IR, aliases []string, names map[string]bool) []*tagGroup {
+	tagOf := func(path, method string) string {
+		if tags := ir.Paths[path].Ops[method].Tags; len(tags) > 0 && tags[0] != "" {
+			return tags[0]
+		}
+		return untaggedTag
+	}
+
+	byTag := map[string]*tagGroup{}
+	for path, item := range ir.Paths {
+		for method, op := range item.Ops {
+			tag := tagOf(path, method)
+			g := byTag[tag]
+			if g == nil {
+				g = &tagGroup{paths: map[string]IRPathItem{}}
+				byTag[tag] = g
+			}
+			if _, ok := g.paths[path]; !ok {
+				g.paths[path] = IRPathItem{Ops: map[string]IROperation{}}
+			}
+			g.paths[path].Ops[method] = op
+		}
+	}
+	for i, op := range ir.Operations {
+		g := byTag[tagOf(op.Path, op.Method)]
+		g.ops = append(g.ops, op)
+		if aliases != nil {
+			g.aliases = append(g.aliases, aliases[i])
+		}
+	}
+
+	tags := make([]string, 0, len(byTag))
+	for tag := range byTag {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		a, b := strings.ToLower(tags[i]), strings.ToLower(tags[j])
+		if a != b {
+			return a < b
+		}
+		return tags[i] < tags[j]
+	})
+
+	files := map[string]bool{}
+	groups := make([]*tagGroup, 0, len(tags))
+	for _, tag := range tags {
+		g := byTag[tag]
+		g.module = reserveName(tagSlug(tag), files) + "." + routesModule
+		g.routes = reserveName(pascalIdent(tag)+"Routes", names)
+		g.opsName = reserveName(pascalIdent(tag)+"Operations", names)
+		groups = append(groups, g)
+	}
+	return groups
+}
+
+func tagSlug(tag string) string {
+	parts := strings.FieldsFunc(strings.ToLower(tag), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(parts) == 0 {
+		return "tag"
+	}
+	return strings.Join(parts, "-")
+}
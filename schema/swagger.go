@@ -0,0 +1,693 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+var ErrUnsupportedSwaggerVersion = errors.New("unsupported swagger version")
+
+const swaggerConvertedVersion = "3.0.3"
+
+var swaggerRefPrefixes = [][2]string{
+	{"#/definitions/", "#/components/schemas/"},
+	{"#/parameters/", "#/components/parameters/"},
+	{"#/responses/", "#/components/responses/"},
+}
+
+var swaggerSchemaKeys = []string{
+	"type",
+	"format",
+	"items",
+	"default",
+	"maximum",
+	"exclusiveMaximum",
+	"minimum",
+	"exclusiveMinimum",
+	"maxLength",
+	"minLength",
+	"pattern",
+	"maxItems",
+	"minItems",
+	"uniqueItems",
+	"enum",
+	"multipleOf",
+	"x-nullable",
+}
+
+var swaggerOAuthFlows = map[string]string{
+	"implicit":    "implicit",
+	"password":    "password",
+	"application": "clientCredentials",
+	"accessCode":  "authorizationCode",
+}
+
+type swaggerConverter struct {
+	root     *yaml.Node
+	params   *yaml.Node
+	consumes []string
+	produces []string
+}
+
+func isSwagger2(root *yaml.Node) (bool, error) {
+	v := mappingValue(root, "swagger")
+	if v == nil {
+		return false, nil
+	}
+	if !strings.HasPrefix(v.Value, "2.") {
+		return false, fmt.Errorf("%w: %q", ErrUnsupportedSwaggerVersion, v.Value)
+	}
+	return true, nil
+}
+
+func convertSwagger2(root *yaml.Node) *yaml.Node {
+	c := &swaggerConverter{
+		root:     root,
+		params:   mappingValue(root, "parameters"),
+		consumes: scalarList(mappingValue(root, "consumes")),
+		produces: scalarList(mappingValue(root, "produces")),
+	}
+
+	out := mapNode()
+	setKey(out, "openapi", stringNode(swaggerConvertedVersion, 0, 0))
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, val := root.Content[i].Value, root.Content[i+1]
+		switch key {
+		case "swagger", "host", "basePath", "schemes", "consumes", "produces",
+			"definitions", "parameters", "responses", "securityDefinitions":
+			continue
+		case "paths":
+			setKey(out, key, c.paths(val))
+		default:
+			setKey(out, key, copyNode(val))
+		}
+	}
+	if servers := c.servers(); servers != nil {
+		setKey(out, "servers", servers)
+	}
+	if components := c.components(); len(components.Content) > 0 {
+		setKey(out, "components", components)
+	}
+	rewriteSwaggerDocument(out)
+	return out
+}
+
+func (c *swaggerConverter) servers() *yaml.Node {
+	host := scalarValue(c.root, "host")
+	basePath := scalarValue(c.root, "basePath")
+	if host == "" && basePath == "" {
+		return nil
+	}
+	if host == "" {
+		return seqNode(mapWith("url", stringNode(basePath, 0, 0)))
+	}
+	schemes := scalarList(mappingValue(c.root, "schemes"))
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+	servers := make([]*yaml.Node, 0, len(schemes))
+	for _, s := range schemes {
+		servers = append(servers, mapWith("url", stringNode(s+"://"+host+basePath, 0, 0)))
+	}
+	return seqNode(servers...)
+}
+
+func (c *swaggerConverter) components() *yaml.Node {
+	out := mapNode()
+	if defs := mappingValue(c.root, "definitions"); defs != nil && defs.Kind == yaml.MappingNode {
+		setKey(out, "schemas", copyNode(defs))
+	}
+
+	if c.params != nil && c.params.Kind == yaml.MappingNode {
+		params := mapNode()
+		bodies := mapNode()
+		for i := 0; i+1 < len(c.params.Content); i += 2 {
+			name, p := c.params.Content[i].Value, c.params.Content[i+1]
+			switch scalarValue(p, "in") {
+			case "body":
+				setKey(bodies, name, bodyFromSwagger(p, c.consumes))
+			case "formData":
+				continue
+			default:
+				setKey(params, name, parameterFromSwagger(p))
+			}
+		}
+		if len(params.Content) > 0 {
+			setKey(out, "parameters", params)
+		}
+		if len(bodies.Content) > 0 {
+			setKey(out, "requestBodies", bodies)
+		}
+	}
+
+	if responses := mappingValue(c.root, "responses"); responses != nil && responses.Kind == yaml.MappingNode {
+		out2 := mapNode()
+		for i := 0; i+1 < len(responses.Content); i += 2 {
+			setKey(out2, responses.Content[i].Value, responseFromSwagger(responses.Content[i+1], c.produces))
+		}
+		setKey(out, "responses", out2)
+	}
+
+	if defs := mappingValue(c.root, "securityDefinitions"); defs != nil && defs.Kind == yaml.MappingNode {
+		schemes := mapNode()
+		for i := 0; i+1 < len(defs.Content); i += 2 {
+			setKey(schemes, defs.Content[i].Value, securitySchemeFromSwagger(defs.Content[i+1]))
+		}
+		setKey(out, "securitySchemes", schemes)
+	}
+	return out
+}
+
+func (c *swaggerConverter) paths(paths *yaml.Node) *yaml.Node {
+	if paths == nil || paths.Kind != yaml.MappingNode {
+		return copyNode(paths)
+	}
+	out := mapNode()
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		setKey(out, paths.Content[i].Value, c.pathItem(paths.Content[i+1]))
+	}
+	return out
+}
+
+func (c *swaggerConverter) pathItem(item *yaml.Node) *yaml.Node {
+	if item == nil || item.Kind != yaml.MappingNode || mappingValue(item, "$ref") != nil {
+		return copyNode(item)
+	}
+	shared := mappingValue(item, "parameters")
+	out := mapNode()
+	for i := 0; i+1 < len(item.Content); i += 2 {
+		key, val := item.Content[i].Value, item.Content[i+1]
+		switch key {
+		case "parameters":
+			if params := c.plainParameters(val); params != nil {
+				setKey(out, key, params)
+			}
+		case "get", "put", "post", "delete", "options", "head", "patch":
+			setKey(out, key, c.operation(val, shared))
+		default:
+			setKey(out, key, copyNode(val))
+		}
+	}
+	return out
+}
+
+func (c *swaggerConverter) plainParameters(list *yaml.Node) *yaml.Node {
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return nil
+	}
+	out := []*yaml.Node{}
+	for _, p := range list.Content {
+		switch scalarValue(c.resolveParam(p), "in") {
+		case "body", "formData":
+			continue
+		}
+		out = append(out, c.convertParamEntry(p))
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return seqNode(out...)
+}
+
+func (c *swaggerConverter) operation(op, shared *yaml.Node) *yaml.Node {
+	if op == nil || op.Kind != yaml.MappingNode {
+		return copyNode(op)
+	}
+	consumes := c.consumes
+	if v := mappingValue(op, "consumes"); v != nil {
+		consumes = scalarList(v)
+	}
+	produces := c.produces
+	if v := mappingValue(op, "produces"); v != nil {
+		produces = scalarList(v)
+	}
+
+	out := mapNode()
+	for i := 0; i+1 < len(op.Content); i += 2 {
+		key, val := op.Content[i].Value, op.Content[i+1]
+		switch key {
+		case "consumes", "produces", "parameters", "schemes":
+			continue
+		case "responses":
+			setKey(out, key, responsesFromSwagger(val, produces))
+		default:
+			setKey(out, key, copyNode(val))
+		}
+	}
+
+	if params := c.plainParameters(mappingValue(op, "parameters")); params != nil {
+		setKey(out, "parameters", params)
+	}
+
+	var (
+		form     []*yaml.Node
+		body     *yaml.Node
+		bodyName string
+	)
+	for _, p := range c.mergedParams(shared, mappingValue(op, "parameters")) {
+		target := c.resolveParam(p)
+		switch scalarValue(target, "in") {
+		case "body":
+			body, bodyName = target, ""
+			if ref := scalarValue(p, "$ref"); strings.HasPrefix(ref, "#/parameters/") {
+				bodyName = strings.TrimPrefix(ref, "#/parameters/")
+			}
+		case "formData":
+			form = append(form, target)
+		}
+	}
+	switch {
+	case bodyName != "":
+		setKey(out, "requestBody", mapWith("$ref", stringNode("#/components/requestBodies/"+bodyName, 0, 0)))
+	case body != nil:
+		setKey(out, "requestBody", bodyFromSwagger(body, consumes))
+	case len(form) > 0:
+		setKey(out, "requestBody", formRequest(form, consumes))
+	}
+	return out
+}
+
+func (c *swaggerConverter) mergedParams(shared, own *yaml.Node) []*yaml.Node {
+	var out []*yaml.Node
+	index := map[string]int{}
+	for _, list := range []*yaml.Node{shared, own} {
+		if list == nil || list.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, p := range list.Content {
+			target := c.resolveParam(p)
+			key := scalarValue(target, "in") + ":" + scalarValue(target, "name")
+			if i, ok := index[key]; ok {
+				out[i] = p
+				continue
+			}
+			index[key] = len(out)
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func (c *swaggerConverter) resolveParam(p *yaml.Node) *yaml.Node {
+	ref := scalarValue(p, "$ref")
+	if !strings.HasPrefix(ref, "#/parameters/") {
+		return p
+	}
+	if target := mappingValue(c.params, unescapePointerToken(strings.TrimPrefix(ref, "#/parameters/"))); target != nil {
+		return target
+	}
+	return p
+}
+
+func (c *swaggerConverter) convertParamEntry(p *yaml.Node) *yaml.Node {
+	if mappingValue(p, "$ref") != nil {
+		return copyNode(p)
+	}
+	return parameterFromSwagger(p)
+}
+
+func parameterFromSwagger(p *yaml.Node) *yaml.Node {
+	if p == nil || p.Kind != yaml.MappingNode || mappingValue(p, "$ref") != nil {
+		return copyNode(p)
+	}
+	in := scalarValue(p, "in")
+	out := mapNode()
+	for i := 0; i+1 < len(p.Content); i += 2 {
+		key := p.Content[i].Value
+		switch key {
+		case "name", "in", "description", "required", "deprecated", "allowEmptyValue":
+			setKey(out, key, copyNode(p.Content[i+1]))
+		default:
+			if strings.HasPrefix(key, "x-") && key != "x-nullable" {
+				setKey(out, key, copyNode(p.Content[i+1]))
+			}
+		}
+	}
+	setKey(out, "schema", schemaFromSwaggerParam(p))
+	if scalarValue(p, "type") == "array" && (in == "query" || in == "cookie") {
+		switch collectionFormat(p) {
+		case "csv":
+			setKey(out, "explode", boolNode(false))
+		case "ssv":
+			setKey(out, "style", stringNode("spaceDelimited", 0, 0))
+			setKey(out, "explode", boolNode(false))
+		case "pipes":
+			setKey(out, "style", stringNode("pipeDelimited", 0, 0))
+			setKey(out, "explode", boolNode(false))
+		}
+	}
+	return out
+}
+
+func bodyFromSwagger(p *yaml.Node, consumes []string) *yaml.Node {
+	out := mapNode()
+	if d := mappingValue(p, "description"); d != nil {
+		setKey(out, "description", copyNode(d))
+	}
+	content := mapNode()
+	for _, ct := range mediaTypesOrJSON(consumes) {
+		setKey(content, ct, mapWith("schema", copyNode(mappingValue(p, "schema"))))
+	}
+	setKey(out, "content", content)
+	if r := mappingValue(p, "required"); r != nil {
+		setKey(out, "required", copyNode(r))
+	}
+	return out
+}
+
+func formRequest(params []*yaml.Node, consumes []string) *yaml.Node {
+	props := mapNode()
+	var required []*yaml.Node
+	hasFile := false
+	for _, p := range params {
+		name := scalarValue(p, "name")
+		prop := schemaFromSwaggerParam(p)
+		if d := mappingValue(p, "description"); d != nil {
+			setKey(prop, "description", copyNode(d))
+		}
+		setKey(props, name, prop)
+		if scalarValue(p, "required") == "true" {
+			required = append(required, stringNode(name, 0, 0))
+		}
+		if scalarValue(p, "type") == "file" {
+			hasFile = true
+		}
+	}
+	schema := mapWith("type", stringNode("object", 0, 0))
+	setKey(schema, "properties", props)
+	if len(required) > 0 {
+		setKey(schema, "required", seqNode(required...))
+	}
+
+	var types []string
+	for _, ct := range consumes {
+		if ct == "multipart/form-data" || ct == "application/x-www-form-urlencoded" {
+			types = append(types, ct)
+		}
+	}
+	if len(types) == 0 {
+		types = []string{"application/x-www-form-urlencoded"}
+		if hasFile {
+			types = []string{"multipart/form-data"}
+		}
+	}
+	content := mapNode()
+	for _, ct := range types {
+		setKey(content, ct, mapWith("schema", copyNode(schema)))
+	}
+	out := mapWith("content", content)
+	if len(required) > 0 {
+		setKey(out, "required", boolNode(true))
+	}
+	return out
+}
+
+func responsesFromSwagger(responses *yaml.Node, produces []string) *yaml.Node {
+	if responses == nil || responses.Kind != yaml.MappingNode {
+		return copyNode(responses)
+	}
+	out := mapNode()
+	for i := 0; i+1 < len(responses.Content); i += 2 {
+		setKey(out, responses.Content[i].Value, responseFromSwagger(responses.Content[i+1], produces))
+	}
+	return out
+}
+
+func responseFromSwagger(r *yaml.Node, produces []string) *yaml.Node {
+	if r == nil || r.Kind != yaml.MappingNode || mappingValue(r, "$ref") != nil {
+		return copyNode(r)
+	}
+	out := mapNode()
+	setKey(out, "description", stringNode(scalarValue(r, "description"), 0, 0))
+	for i := 0; i+1 < len(r.Content); i += 2 {
+		key, val := r.Content[i].Value, r.Content[i+1]
+		switch key {
+		case "description", "schema", "examples":
+			continue
+		case "headers":
+			headers := mapNode()
+			for j := 0; j+1 < len(val.Content); j += 2 {
+				setKey(headers, val.Content[j].Value, headerFromSwagger(val.Content[j+1]))
+			}
+			setKey(out, key, headers)
+		default:
+			setKey(out, key, copyNode(val))
+		}
+	}
+	schema := mappingValue(r, "schema")
+	if schema == nil {
+		return out
+	}
+	examples := mappingValue(r, "examples")
+	content := mapNode()
+	for _, ct := range mediaTypesOrJSON(produces) {
+		mt := mapWith("schema", copyNode(schema))
+		if ex := mappingValue(examples, ct); ex != nil {
+			setKey(mt, "example", copyNode(ex))
+		}
+		setKey(content, ct, mt)
+	}
+	setKey(out, "content", content)
+	return out
+}
+
+func headerFromSwagger(h *yaml.Node) *yaml.Node {
+	if h == nil || h.Kind != yaml.MappingNode {
+		return copyNode(h)
+	}
+	out := mapNode()
+	if d := mappingValue(h, "description"); d != nil {
+		setKey(out, "description", copyNode(d))
+	}
+	setKey(out, "schema", schemaFromSwaggerParam(h))
+	return out
+}
+
+func securitySchemeFromSwagger(s *yaml.Node) *yaml.Node {
+	if s == nil || s.Kind != yaml.MappingNode {
+		return copyNode(s)
+	}
+	out := mapNode()
+	switch scalarValue(s, "type") {
+	case "basic":
+		setKey(out, "type", stringNode("http", 0, 0))
+		setKey(out, "scheme", stringNode("basic", 0, 0))
+	case "oauth2":
+		setKey(out, "type", stringNode("oauth2", 0, 0))
+		flow := mapNode()
+		for _, key := range []string{"authorizationUrl", "tokenUrl"} {
+			if v := mappingValue(s, key); v != nil {
+				setKey(flow, key, copyNode(v))
+			}
+		}
+		scopes := mappingValue(s, "scopes")
+		if scopes == nil {
+			scopes = mapNode()
+		}
+		setKey(flow, "scopes", copyNode(scopes))
+		name, ok := swaggerOAuthFlows[scalarValue(s, "flow")]
+		if !ok {
+			name = "implicit"
+		}
+		setKey(out, "flows", mapWith(name, flow))
+	default:
+		for _, key := range []string{"type", "name", "in"} {
+			if v := mappingValue(s, key); v != nil {
+				setKey(out, key, copyNode(v))
+			}
+		}
+	}
+	if d := mappingValue(s, "description"); d != nil {
+		setKey(out, "description", copyNode(d))
+	}
+	return out
+}
+
+func schemaFromSwaggerParam(p *yaml.Node) *yaml.Node {
+	out := mapNode()
+	for _, key := range swaggerSchemaKeys {
+		v := mappingValue(p, key)
+		if v == nil {
+			continue
+		}
+		if key == "items" {
+			v = schemaFromSwaggerParam(v)
+		} else {
+			v = copyNode(v)
+		}
+		setKey(out, key, v)
+	}
+	return out
+}
+
+func collectionFormat(p *yaml.Node) string {
+	if cf := scalarValue(p, "collectionFormat"); cf != "" {
+		return cf
+	}
+	return "csv"
+}
+
+func rewriteSwaggerDocument(doc *yaml.Node) {
+	components := mappingValue(doc, "components")
+	eachMappingValue(mappingValue(components, "schemas"), rewriteSwaggerSchema)
+	eachMappingValue(mappingValue(components, "parameters"), rewriteSwaggerParameter)
+	eachMappingValue(mappingValue(components, "requestBodies"), rewriteSwaggerContent)
+	eachMappingValue(mappingValue(components, "responses"), rewriteSwaggerResponse)
+
+	eachMappingValue(mappingValue(doc, "paths"), func(item *yaml.Node) {
+		rewriteSwaggerRef(item)
+		eachSequenceItem(mappingValue(item, "parameters"), rewriteSwaggerParameter)
+		for _, method := range pathItemMethods {
+			op := mappingValue(item, method)
+			if op == nil {
+				continue
+			}
+			eachSequenceItem(mappingValue(op, "parameters"), rewriteSwaggerParameter)
+			rewriteSwaggerContent(mappingValue(op, "requestBody"))
+			eachMappingValue(mappingValue(op, "responses"), rewriteSwaggerResponse)
+		}
+	})
+}
+
+func rewriteSwaggerParameter(p *yaml.Node) {
+	rewriteSwaggerRef(p)
+	rewriteSwaggerSchema(mappingValue(p, "schema"))
+}
+
+func rewriteSwaggerResponse(r *yaml.Node) {
+	rewriteSwaggerContent(r)
+	eachMappingValue(mappingValue(r, "headers"), func(h *yaml.Node) {
+		rewriteSwaggerSchema(mappingValue(h, "schema"))
+	})
+}
+
+func rewriteSwaggerContent(n *yaml.Node) {
+	rewriteSwaggerRef(n)
+	eachMappingValue(mappingValue(n, "content"), func(mt *yaml.Node) {
+		rewriteSwaggerSchema(mappingValue(mt, "schema"))
+	})
+}
+
+func rewriteSwaggerSchema(n *yaml.Node) {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return
+	}
+	rewriteSwaggerRef(n)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, val := n.Content[i], n.Content[i+1]
+		switch key.Value {
+		case "x-nullable":
+			key.Value = "nullable"
+		case "discriminator":
+			if val.Kind == yaml.ScalarNode {
+				prop := stringNode(val.Value, val.Line, val.Column)
+				n.Content[i+1] = mapWith("propertyName", prop)
+			}
+		case "type":
+			if val.Value == "file" {
+				val.Value = "string"
+				if mappingValue(n, "format") == nil {
+					n.Content = append(n.Content, stringNode("format", 0, 0), stringNode("binary", 0, 0))
+				}
+			}
+		case "items", "additionalProperties", "not":
+			rewriteSwaggerSchema(val)
+			eachSequenceItem(val, rewriteSwaggerSchema)
+		case "allOf", "anyOf", "oneOf":
+			eachSequenceItem(val, rewriteSwaggerSchema)
+		case "properties":
+			eachMappingValue(val, rewriteSwaggerSchema)
+		}
+	}
+}
+
+func rewriteSwaggerRef(n *yaml.Node) {
+	ref := mappingValue(n, "$ref")
+	if ref == nil || ref.Kind != yaml.ScalarNode {
+		return
+	}
+	for _, p := range swaggerRefPrefixes {
+		if strings.HasPrefix(ref.Value, p[0]) {
+			ref.Value = p[1] + strings.TrimPrefix(ref.Value, p[0])
+			return
+		}
+	}
+}
+
+func eachMappingValue(n *yaml.Node, fn func(*yaml.Node)) {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 1; i < len(n.Content); i += 2 {
+		fn(n.Content[i])
+	}
+}
+
+func eachSequenceItem(n *yaml.Node, fn func(*yaml.Node)) {
+	if n == nil || n.Kind != yaml.SequenceNode {
+		return
+	}
+	for _, c := range n.Content {
+		fn(c)
+	}
+}
+
+func mediaTypesOrJSON(types []string) []string {
+	if len(types) == 0 {
+		return []string{"application/json"}
+	}
+	return types
+}
+
+func scalarValue(n *yaml.Node, key string) string {
+	v := mappingValue(n, key)
+	if v == nil || v.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return v.Value
+}
+
+func scalarList(n *yaml.Node) []string {
+	if n == nil || n.Kind != yaml.SequenceNode {
+		return nil
+	}
+	out := make([]string, 0, len(n.Content))
+	for _, c := range n.Content {
+		if c.Kind == yaml.ScalarNode {
+			out = append(out, c.Value)
+		}
+	}
+	return out
+}
+
+func mapNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+func mapWith(key string, value *yaml.Node) *yaml.Node {
+	n := mapNode()
+	setKey(n, key, value)
+	return n
+}
+
+func seqNode(items ...*yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: items}
+}
+
+func boolNode(v bool) *yaml.Node {
+	value := "false"
+	if v {
+		value = "true"
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: value}
+}
+
+func setKey(n *yaml.Node, key string, value *yaml.Node) {
+	if value == nil {
+		return
+	}
+	n.Content = append(n.Content, stringNode(key, 0, 0), value)
+}
@@ -0,0 +1,136 @@
+package schema
+
+import "sort"
+
+type TypeKind int
+
+type TypeNode struct {
+	Literal any
+	Elem    *TypeNode
+	Key     *TypeNode
+	Schema  map[string]any
+	Name    string
+	Section string
+	Items   []*TypeNode
+	Fields  []Field
+	Kind    TypeKind
+}
+
+type Field struct {
+	Type     *TypeNode
+	Name     string
+	Doc      []string
+	Optional bool
+}
+
+type IREnum struct {
+	Name    string
+	Members []EnumMember
+}
+
+type EnumMember struct {
+	Value any
+	Name  string
+	Doc   []string
+}
+
+func unknownType() *TypeNode {
+	return &TypeNode{Kind: TypeUnknown}
+}
+
+func neverType() *TypeNode {
+	return &TypeNode{Kind: TypeNever}
+}
+
+func primitiveType(name string) *TypeNode {
+	return &TypeNode{Kind: TypePrimitive, Name: name}
+}
+
+func stringType() *TypeNode {
+	return primitiveType(schemaTypeString)
+}
+
+func nullType() *TypeNode {
+	return primitiveType(schemaTypeNull)
+}
+
+func literalType(v any) *TypeNode {
+	if v == nil {
+		return nullType()
+	}
+	return &TypeNode{Kind: TypeLiteral, Literal: v}
+}
+
+func literalValue(v any) (*TypeNode, bool) {
+	if literalToTS(v) == "" {
+		return nil, false
+	}
+	return literalType(v), true
+}
+
+func templateType(prefix string, placeholder *TypeNode) *TypeNode {
+	return &TypeNode{Kind: TypeTemplate, Name: prefix, Elem: placeholder}
+}
+
+func arrayType(elem *TypeNode) *TypeNode {
+	return &TypeNode{Kind: TypeArray, Elem: elem}
+}
+
+func tupleType(items ...*TypeNode) *TypeNode {
+	return &TypeNode{Kind: TypeTuple, Items: items}
+}
+
+func optionalType(elem *TypeNode) *TypeNode {
+	return &TypeNode{Kind: TypeOptional, Elem: elem}
+}
+
+func restType(elem *TypeNode) *TypeNode {
+	return &TypeNode{Kind: TypeRest, Elem: arrayType(elem)}
+}
+
+func objectType(fields []Field) *TypeNode {
+	return &TypeNode{Kind: TypeObject, Fields: fields}
+}
+
+func sortedObjectType(fields []Field) *TypeNode {
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name < fields[j].Name
+	})
+	return objectType(fields)
+}
+
+func recordType(key, value *TypeNode) *TypeNode {
+	return &TypeNode{Kind: TypeRecord, Key: key, Elem: value}
+}
+
+func mappedType(key, value *TypeNode) *TypeNode {
+	return &TypeNode{Kind: TypeMapped, Key: key, Elem: value}
+}
+
+func unionOf(items ...*TypeNode) *TypeNode {
+	return &TypeNode{Kind: TypeUnion, Items: items}
+}
+
+func intersectionOf(items ...*TypeNode) *TypeNode {
+	return &TypeNode{Kind: TypeIntersection, Items: items}
+}
+
+func omitType(base, keys *TypeNode) *TypeNode {
+	return &TypeNode{Kind: TypeOmit, Elem: base, Key: keys}
+}
+
+func refType(section, name string) *TypeNode {
+	return &TypeNode{Kind: TypeRef, Section: section, Name: name}
+}
+
+func enumType(name string) *TypeNode {
+	return &TypeNode{Kind: TypeEnum, Name: name}
+}
+
+func isPrimitive(t *TypeNode, name string) bool {
+	return t != nil && t.Kind == TypePrimitive && t.Name == name
+}
+
+func typeKey(t *TypeNode) string {
+	return RenderTS(t)
+}
@@ -0,0 +1,154 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrUnknownEnumStyle = errors.New("unknown enum style")
+
+type EnumStyle string
+
+const (
+	EnumConst  EnumStyle = "const-enum"
+	EnumPlain  EnumStyle = "enum"
+	EnumUnion  EnumStyle = "union"
+	EnumObject EnumStyle = "object"
+)
+
+func ParseEnumStyle(s string) (EnumStyle, error) {
+	switch style := EnumStyle(s); style {
+	case "":
+		return EnumConst, nil
+	case EnumConst, EnumPlain, EnumUnion, EnumObject:
+		return style, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownEnumStyle, s)
+	}
+}
+
+func RenderTS(t *TypeNode) string {
+	if t == nil {
+		return tsUnknown
+	}
+	switch t.Kind {
+	case TypeNever:
+		return tsNever
+	case TypePrimitive, TypeEnum, TypeBrand:
+		return t.Name
+	case TypeLiteral:
+		return literalToTS(t.Literal)
+	case TypeTemplate:
+		return "`" + t.Name + "${" + RenderTS(t.Elem) + "}`"
+	case TypeArray:
+		return RenderTS(t.Elem) + "[]"
+	case TypeTuple:
+		return "[" + renderTSList(t.Items, ", ") + "]"
+	case TypeOptional:
+		return RenderTS(t.Elem) + "?"
+	case TypeRest:
+		return "..." + RenderTS(t.Elem)
+	case TypeObject:
+		return renderTSObject(t.Fields)
+	case TypeRecord:
+		return "Record<" + RenderTS(t.Key) + ", " + RenderTS(t.Elem) + ">"
+	case TypeMapped:
+		return "{ [K in " + RenderTS(t.Key) + "]?: " + RenderTS(t.Elem) + " }"
+	case TypeUnion:
+		return "(" + renderTSList(t.Items, " | ") + ")"
+	case TypeIntersection:
+		return "(" + renderTSList(t.Items, " & ") + ")"
+	case TypeOmit:
+		return "Omit<" + RenderTS(t.Elem) + ", " + RenderTS(t.Key) + ">"
+	case TypeRef:
+		return "Components[" + strconv.Quote(t.Section) + "][" + strconv.Quote(t.Name) + "]"
+	default:
+		return tsUnknown
+	}
+}
+
+func renderTSList(items []*TypeNode, sep string) string {
+	parts := make([]string, 0, len(items))
+	for _, it := range items {
+		parts = append(parts, RenderTS(it))
+	}
+	return strings.Join(parts, sep)
+}
+
+func renderTSObject(fields []Field) string {
+	if len(fields) == 0 {
+		return tsEmptyObject
+	}
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, f := range fields {
+		b.WriteString(jsDoc("  ", f.Doc))
+		key := safeProp(f.Name)
+		if f.Optional {
+			key += "?"
+		}
+		writeTSField(&b, "  ", key, RenderTS(f.Type))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func renderTSEnum(e IREnum, style EnumStyle) string {
+	var b strings.Builder
+	switch style {
+	case EnumUnion:
+		values := make([]string, 0, len(e.Members))
+		for _, m := range e.Members {
+			values = append(values, literalToTS(m.Value))
+		}
+		b.WriteString("export type " + e.Name + " = " + strings.Join(values, " | ") + ";\n\n")
+	case EnumObject:
+		b.WriteString("export const " + e.Name + " = {\n")
+		for _, m := range e.Members {
+			b.WriteString(jsDoc("  ", m.Doc))
+			b.WriteString("  " + m.Name + ": " + literalToTS(m.Value) + ",\n")
+		}
+		b.WriteString("} as const;\n")
+		b.WriteString("export type " + e.Name + " = (typeof " + e.Name + ")[keyof typeof " + e.Name + "];\n\n")
+	default:
+		keyword := "export const enum "
+		if style == EnumPlain {
+			keyword = "export enum "
+		}
+		b.WriteString(keyword + e.Name + " {\n")
+		for _, m := range e.Members {
+			b.WriteString(jsDoc("  ", m.Doc))
+			b.WriteString("  " + m.Name + " = " + literalToTS(m.Value) + ",\n")
+		}
+		b.WriteString("}\n\n")
+	}
+	return b.String()
+}
+
+func literalToTS(v any) string {
+	switch val := v.(type) {
+	case string:
+		return strconv.Quote(val)
+	case bool:
+		if val {
+			return "true"
+		}
+		return "false"
+	case int:
+		return strconv.Itoa(val)
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case int32:
+		return strconv.FormatInt(int64(val), 10)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(val), 'f', -1, 32)
+	case nil:
+		return schemaTypeNull
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,347 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	pathTemplateParam = regexp.MustCompile(`\{([^{}]+)\}`)
+	statusCodeKey     = regexp.MustCompile(`^([1-5][0-9][0-9]|[1-5]XX|default)$`)
+	openAPIVersion    = regexp.MustCompile(`^3\.[01]\.\d+$`)
+)
+
+var pathItemMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+type ValidationError struct {
+	File          string
+	Message       string
+	RelatedFile   string
+	Line          int
+	Column        int
+	RelatedLine   int
+	RelatedColumn int
+}
+
+func (e ValidationError) String() string {
+	msg := e.Message
+	if e.RelatedFile != "" {
+		msg += fmt.Sprintf(" (first used at %s:%d:%d)", e.RelatedFile, e.RelatedLine, e.RelatedColumn)
+	}
+	if e.Line == 0 {
+		return e.File + ": " + msg
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, msg)
+}
+
+type validator struct {
+	origins map[*yaml.Node]string
+	opIDs   map[string]ValidationError
+	schemes map[string]bool
+	badRefs map[*yaml.Node]bool
+	root    *yaml.Node
+	rootDoc string
+	errs    []ValidationError
+}
+
+type declaredParam struct {
+	node *yaml.Node
+	file string
+	name string
+	in   string
+}
+
+func Validate(path string, format InputFormat) ([]ValidationError, error) {
+	_, l, err := loadDocumentWith(path, format, true)
+	if err != nil {
+		return nil, err
+	}
+	return validateLoaded(l), nil
+}
+
+func ValidateData(data []byte, format InputFormat) ([]ValidationError, error) {
+	l, err := dataLoader(data, "")
+	if err != nil {
+		return nil, err
+	}
+	l.collectRefErrs = true
+	if _, err := l.load(stdinName, format); err != nil {
+		return nil, err
+	}
+	return validateLoaded(l), nil
+}
+
+func validateLoaded(l *loader) []ValidationError {
+	root := l.files[l.root]
+	if root == nil {
+		return []ValidationError{{File: l.root, Message: "document is empty"}}
+	}
+
+	v := &validator{
+		origins: l.origins,
+		opIDs:   map[string]ValidationError{},
+		schemes: map[string]bool{},
+		badRefs: map[*yaml.Node]bool{},
+		root:    root,
+		rootDoc: l.root,
+	}
+	for _, re := range l.refErrs {
+		v.badRefs[re.node] = true
+		if errors.Is(re.err, ErrRefCycle) {
+			v.errorf(re.node, re.file, "$ref %q is part of a cycle", re.node.Value)
+		} else {
+			v.errorf(re.node, re.file, "unresolved $ref %q", re.node.Value)
+		}
+	}
+	v.validateDocument()
+	sort.SliceStable(v.errs, func(i, j int) bool {
+		a, b := v.errs[i], v.errs[j]
+		if a.File != b.File {
+			return a.File == v.rootDoc || (b.File != v.rootDoc && a.File < b.File)
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return v.errs
+}
+
+func (v *validator) errorf(n *yaml.Node, file, format string, args ...any) {
+	e := ValidationError{File: file, Message: fmt.Sprintf(format, args...)}
+	if n != nil {
+		e.Line, e.Column = n.Line, n.Column
+	}
+	v.errs = append(v.errs, e)
+}
+
+func (v *validator) fileOf(n *yaml.Node, parent string) string {
+	if f, ok := v.origins[n]; ok {
+		return f
+	}
+	return parent
+}
+
+func (v *validator) validateDocument() {
+	file := v.rootDoc
+	if ver := mappingValue(v.root, "openapi"); ver == nil {
+		v.errorf(v.root, file, "missing required field %q", "openapi")
+	} else if !openAPIVersion.MatchString(ver.Value) {
+		v.errorf(ver, file, "unsupported openapi version %q, expected 3.0.x or 3.1.x", ver.Value)
+	}
+
+	if info := mappingValue(v.root, "info"); info == nil {
+		v.errorf(v.root, file, "missing required field %q", "info")
+	} else {
+		for _, key := range []string{"title", "version"} {
+			if mappingValue(info, key) == nil {
+				v.errorf(info, file, "info is missing required field %q", key)
+			}
+		}
+	}
+
+	if schemes := mappingValue(mappingValue(v.root, "components"), "securitySchemes"); schemes != nil {
+		for i := 0; i+1 < len(schemes.Content); i += 2 {
+			v.schemes[schemes.Content[i].Value] = true
+		}
+	}
+	v.validateSecurity(mappingValue(v.root, "security"), file)
+
+	if paths := mappingValue(v.root, "paths"); paths != nil {
+		for i := 0; i+1 < len(paths.Content); i += 2 {
+			key, item := paths.Content[i], paths.Content[i+1]
+			if !strings.HasPrefix(key.Value, "/") {
+				v.errorf(key, file, "path %q must start with /", key.Value)
+			}
+			v.validatePathItem(key.Value, item, v.fileOf(item, file), true)
+		}
+	}
+	if webhooks := mappingValue(v.root, "webhooks"); webhooks != nil {
+		for i := 0; i+1 < len(webhooks.Content); i += 2 {
+			item := webhooks.Content[i+1]
+			v.validatePathItem(webhooks.Content[i].Value, item, v.fileOf(item, file), false)
+		}
+	}
+
+	v.validateRefs(v.root, file, kindDocument)
+}
+
+func (v *validator) validatePathItem(name string, item *yaml.Node, file string, templated bool) {
+	item, file = v.deref(item, file)
+	if item == nil || item.Kind != yaml.MappingNode {
+		return
+	}
+
+	shared := v.collectParams(mappingValue(item, "parameters"), file)
+	for _, method := range pathItemMethods {
+		op := mappingValue(item, method)
+		if op == nil || op.Kind != yaml.MappingNode {
+			continue
+		}
+		where := strings.ToUpper(method) + " " + name
+
+		if id := mappingValue(op, "operationId"); id != nil {
+			if prev, ok := v.opIDs[id.Value]; ok {
+				v.errorf(id, file, "duplicate operationId %q", id.Value)
+				last := &v.errs[len(v.errs)-1]
+				last.RelatedFile, last.RelatedLine, last.RelatedColumn = prev.File, prev.Line, prev.Column
+			} else {
+				v.opIDs[id.Value] = ValidationError{File: file, Line: id.Line, Column: id.Column}
+			}
+		}
+
+		params := mergeDeclaredParams(shared, v.collectParams(mappingValue(op, "parameters"), file))
+		if templated {
+			v.validatePathParams(name, where, op, file, params)
+		}
+
+		if responses := mappingValue(op, "responses"); responses != nil {
+			for i := 0; i+1 < len(responses.Content); i += 2 {
+				code := responses.Content[i]
+				if !statusCodeKey.MatchString(code.Value) {
+					v.errorf(code, file, "%s: invalid response status code %q", where, code.Value)
+				}
+			}
+		}
+
+		v.validateSecurity(mappingValue(op, "security"), file)
+	}
+}
+
+func (v *validator) validatePathParams(template, where string, op *yaml.Node, file string, params []declaredParam) {
+	names := []string{}
+	inTemplate := map[string]bool{}
+	for _, m := range pathTemplateParam.FindAllStringSubmatch(template, -1) {
+		if !inTemplate[m[1]] {
+			names = append(names, m[1])
+		}
+		inTemplate[m[1]] = true
+	}
+
+	declared := map[string]bool{}
+	for _, p := range params {
+		if p.in != "path" {
+			continue
+		}
+		declared[p.name] = true
+		if !inTemplate[p.name] {
+			v.errorf(p.node, p.file, "%s: path parameter %q is not in the path template", where, p.name)
+		}
+		if req := mappingValue(p.node, "required"); req == nil || req.Value != "true" {
+			v.errorf(p.node, p.file, "%s: path parameter %q must be required", where, p.name)
+		}
+	}
+
+	for _, name := range names {
+		if !declared[name] {
+			v.errorf(op, file, "%s: path template parameter %q is not declared with in: path", where, name)
+		}
+	}
+}
+
+func (v *validator) collectParams(list *yaml.Node, file string) []declaredParam {
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return nil
+	}
+	out := make([]declaredParam, 0, len(list.Content))
+	for _, item := range list.Content {
+		p, pfile := v.deref(item, v.fileOf(item, file))
+		if p == nil || p.Kind != yaml.MappingNode {
+			continue
+		}
+		name, in := mappingValue(p, "name"), mappingValue(p, "in")
+		if name == nil {
+			v.errorf(p, pfile, "parameter is missing required field %q", "name")
+			continue
+		}
+		if in == nil {
+			v.errorf(p, pfile, "parameter %q is missing required field %q", name.Value, "in")
+			continue
+		}
+		out = append(out, declaredParam{node: p, file: pfile, name: name.Value, in: in.Value})
+	}
+	return out
+}
+
+func mergeDeclaredParams(shared, own []declaredParam) []declaredParam {
+	out := make([]declaredParam, 0, len(shared)+len(own))
+	overridden := map[[2]string]bool{}
+	for _, p := range own {
+		overridden[[2]string{p.name, p.in}] = true
+	}
+	for _, p := range shared {
+		if !overridden[[2]string{p.name, p.in}] {
+			out = append(out, p)
+		}
+	}
+	return append(out, own...)
+}
+
+func (v *validator) validateSecurity(reqs *yaml.Node, file string) {
+	if reqs == nil || reqs.Kind != yaml.SequenceNode {
+		return
+	}
+	for _, req := range reqs.Content {
+		if req.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(req.Content); i += 2 {
+			name := req.Content[i]
+			if !v.schemes[name.Value] {
+				v.errorf(name, file, "security requirement references unknown security scheme %q", name.Value)
+			}
+		}
+	}
+}
+
+func (v *validator) validateRefs(n *yaml.Node, file string, kind nodeKind) {
+	if n == nil || kind == kindLiteral || kind == kindOther {
+		return
+	}
+	file = v.fileOf(n, file)
+	switch n.Kind {
+	case yaml.MappingNode:
+		if _, isMap := mapItemKinds[kind]; !isMap || kind == kindCallback {
+			if ref := mappingValue(n, "$ref"); ref != nil && ref.Kind == yaml.ScalarNode && !v.badRefs[ref] {
+				if _, err := v.resolveLocal(ref.Value); err != nil {
+					v.errorf(ref, file, "unresolved $ref %q", ref.Value)
+				}
+			}
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v.validateRefs(n.Content[i+1], file, childKind(kind, n.Content[i].Value))
+		}
+	case yaml.SequenceNode:
+		elem := sequenceItemKind(kind)
+		for _, c := range n.Content {
+			v.validateRefs(c, file, elem)
+		}
+	}
+}
+
+func (v *validator) resolveLocal(ref string) (*yaml.Node, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedRef, ref)
+	}
+	return resolvePointer(v.root, ref[1:])
+}
+
+func (v *validator) deref(n *yaml.Node, file string) (*yaml.Node, string) {
+	for range maxSchemaDepth {
+		ref := mappingValue(n, "$ref")
+		if ref == nil || ref.Kind != yaml.ScalarNode {
+			return n, file
+		}
+		target, err := v.resolveLocal(ref.Value)
+		if err != nil {
+			return nil, file
+		}
+		n, file = target, v.fileOf(target, v.rootDoc)
+	}
+	return nil, file
+}
@@ -1,15 +1,15 @@
 package schema
 
 import (
-	"encoding/json"
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
-
-	"go.yaml.in/yaml/v3"
 )
 
 var Now = time.Now
@@ -19,15 +19,24 @@ var CLIVersion string
 var (
 	ErrSchemaPathRequired = errors.New("schema path is required")
 	ErrOutputPathRequired = errors.New("output path is required")
+	ErrStdoutTypesImport  = errors.New("client needs a types file to import from, not stdout")
+	ErrStdoutSplit        = errors.New("split output needs a directory, not stdout")
+	ErrStdinPath          = errors.New("stdin input must be passed as schema data, not a path")
 )
 
 type InputFormat string
 
 const (
+	InputAuto InputFormat = "auto"
 	InputYAML InputFormat = "yaml"
 	InputJSON InputFormat = "json"
 )
 
+const (
+	StdioPath = "-"
+	stdinName = "<stdin>"
+)
+
 func WriteSchema(schemaPath, outPath string, format InputFormat) error {
 	if schemaPath == "" {
 		return ErrSchemaPathRequired
@@ -36,30 +45,170 @@ func WriteSchema(schemaPath, outPath string, format InputFormat) error {
 		return ErrOutputPathRequired
 	}
 
-	data, err := os.ReadFile(schemaPath)
+	res, err := cliGenerator(Options{Format: format}).generateFile(schemaPath)
+	if err != nil {
+		return err
+	}
+	return writeOutput(outPath, res.Types, os.Stdout)
+}
+
+func WriteClient(schemaPath, clientPath, typesPath string, format InputFormat) error {
+	if schemaPath == "" {
+		return ErrSchemaPathRequired
+	}
+	if clientPath == "" || typesPath == "" {
+		return ErrOutputPathRequired
+	}
+	if typesPath == StdioPath {
+		return ErrStdoutTypesImport
+	}
+
+	typesImport, err := relativeImport(clientPath, typesPath)
+	if err != nil {
+		return err
+	}
+
+	res, err := cliGenerator(Options{Format: format, TypesImport: typesImport, Client: true}).generateFile(schemaPath)
+	if err != nil {
+		return err
+	}
+	return writeOutput(clientPath, res.Client, os.Stdout)
+}
+
+func WriteZod(schemaPath, zodPath string, format InputFormat) error {
+	if schemaPath == "" {
+		return ErrSchemaPathRequired
+	}
+	if zodPath == "" {
+		return ErrOutputPathRequired
+	}
+
+	res, err := cliGenerator(Options{Format: format, Zod: true}).generateFile(schemaPath)
 	if err != nil {
-		return fmt.Errorf("read schema %q: %w", schemaPath, err)
+		return err
+	}
+	return writeOutput(zodPath, res.Zod, os.Stdout)
+}
+
+type OutputPaths struct {
+	Stdout io.Writer
+	Types  string
+	Client string
+	Zod    string
+}
+
+func WriteOutputs(schemaPath string, paths OutputPaths, opts Options) ([]Diagnostic, error) {
+	if schemaPath == "" {
+		return nil, ErrSchemaPathRequired
+	}
+	return writeOutputs(paths, opts, func(g *Generator) (*Result, error) {
+		return g.generateFile(schemaPath)
+	})
+}
+
+func WriteOutputsFrom(data []byte, paths OutputPaths, opts Options) ([]Diagnostic, error) {
+	return writeOutputs(paths, opts, func(g *Generator) (*Result, error) {
+		return g.Generate(bytes.NewReader(data))
+	})
+}
+
+func writeOutputs(paths OutputPaths, opts Options, generate func(*Generator) (*Result, error)) ([]Diagnostic, error) {
+	if paths.Types == "" {
+		return nil, ErrOutputPathRequired
+	}
+
+	if opts.Split != SplitNone && paths.Types == StdioPath {
+		return nil, ErrStdoutSplit
+	}
+
+	opts.Client = paths.Client != ""
+	opts.Zod = paths.Zod != ""
+	if opts.Client {
+		if paths.Types == StdioPath {
+			return nil, ErrStdoutTypesImport
+		}
+		typesImport, err := relativeImport(paths.Client, paths.Types)
+		if err != nil {
+			return nil, err
+		}
+		opts.TypesImport = typesImport
 	}
 
-	var doc Document
-	switch format {
-	case InputJSON:
-		if err := json.Unmarshal(data, &doc); err != nil {
-			return fmt.Errorf("unmarshal schema %q: %w", schemaPath, err)
+	res, err := generate(cliGenerator(opts))
+	if err != nil {
+		return nil, err
+	}
+	stdout := paths.Stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	if res.Files != nil {
+		err = writeGeneratedFiles(paths.Types, res.Files, paths.Client, paths.Zod)
+	} else {
+		err = writeOutput(paths.Types, res.Types, stdout)
+	}
+	if err != nil {
+		return res.Diagnostics, err
+	}
+	if opts.Client {
+		if err := writeOutput(paths.Client, res.Client, stdout); err != nil {
+			return res.Diagnostics, err
 		}
-	default:
-		if err := yaml.Unmarshal(data, &doc); err != nil {
-			return fmt.Errorf("unmarshal schema %q: %w", schemaPath, err)
+	}
+	if opts.Zod {
+		if err := writeOutput(paths.Zod, res.Zod, stdout); err != nil {
+			return res.Diagnostics, err
 		}
 	}
+	return res.Diagnostics, nil
+}
 
-	ir, err := ToIR(&doc)
+func cliGenerator(opts Options) *Generator {
+	opts.Now = Now
+	opts.Version = CLIVersion
+	return NewGenerator(opts)
+}
+
+func relativeImport(fromPath, toPath string) (string, error) {
+	fromAbs, err := filepath.Abs(fromPath)
+	if err != nil {
+		return "", fmt.Errorf("resolve path %q: %w", fromPath, err)
+	}
+	toAbs, err := filepath.Abs(toPath)
+	if err != nil {
+		return "", fmt.Errorf("resolve path %q: %w", toPath, err)
+	}
+	rel, err := filepath.Rel(filepath.Dir(fromAbs), toAbs)
 	if err != nil {
-		return fmt.Errorf("build IR: %w", err)
+		return "", fmt.Errorf("resolve import from %q to %q: %w", fromPath, toPath, err)
+	}
+	rel = filepath.ToSlash(rel)
+	for _, ext := range []string{".d.ts", ".ts", ".tsx"} {
+		if strings.HasSuffix(rel, ext) {
+			rel = strings.TrimSuffix(rel, ext)
+			break
+		}
+	}
+	if rel == "." {
+		rel = "./" + indexModule
+	}
+	if !strings.HasPrefix(rel, ".") {
+		rel = "./" + rel
 	}
+	return rel, nil
+}
 
-	out := normalizeGeneratedOutput(EmitTypesFromIRAt(ir, Now(), CLIVersion, doc.OpenAPI))
+func writeOutput(outPath, out string, stdout io.Writer) error {
+	if outPath == StdioPath {
+		if _, err := io.WriteString(stdout, out); err != nil {
+			return fmt.Errorf("write output to stdout: %w", err)
+		}
+		return nil
+	}
+	return writeGeneratedFile(outPath, out)
+}
 
+func writeGeneratedFile(outPath, out string) error {
 	if existing, err := os.ReadFile(outPath); err == nil {
 		existingNormalized := normalizeGeneratedOutput(string(existing))
 		if stripGeneratedHeader(existingNormalized) == stripGeneratedHeader(out) {
@@ -82,6 +231,55 @@ func WriteSchema(schemaPath, outPath string, format InputFormat) error {
 	return nil
 }
 
+func writeGeneratedFiles(dir string, files map[string]string, keep ...string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read output dir %q: %w", dir, err)
+	}
+	kept := map[string]bool{}
+	for _, path := range keep {
+		if path == "" {
+			continue
+		}
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return fmt.Errorf("resolve path %q: %w", path, err)
+		}
+		kept[abs] = true
+	}
+	for _, e := range entries {
+		if _, ok := files[e.Name()]; ok || e.IsDir() || !isSplitModule(e.Name()) {
+			continue
+		}
+		stale := filepath.Join(dir, e.Name())
+		if abs, err := filepath.Abs(stale); err == nil && kept[abs] {
+			continue
+		}
+		data, err := os.ReadFile(stale)
+		if err != nil {
+			return fmt.Errorf("read output %q: %w", stale, err)
+		}
+		if !isGeneratedOutput(normalizeGeneratedOutput(string(data))) {
+			continue
+		}
+		if err := os.Remove(stale); err != nil {
+			return fmt.Errorf("remove stale output %q: %w", stale, err)
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := writeGeneratedFile(filepath.Join(dir, name), files[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func stripGeneratedHeader(s string) string {
 	if !strings.HasPrefix(s, headerStart()) {
 		return s
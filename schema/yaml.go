@@ -72,12 +72,13 @@ type Document struct {
 	Webhooks          map[string]RefOr[PathItem] `yaml:"webhooks,omitempty" json:"webhooks,omitempty"`
 	Components        *Components                `yaml:"components,omitempty" json:"components,omitempty"`
 	ExternalDocs      *ExternalDocumentation     `yaml:"externalDocs,omitempty" json:"externalDocs,omitempty"`
-	Extensions        Extensions                 `yaml:",inline" json:"-"`
-	OpenAPI           string                     `yaml:"openapi" json:"openapi"`
-	JSONSchemaDialect string                     `yaml:"jsonSchemaDialect,omitempty" json:"jsonSchemaDialect,omitempty"`
-	Servers           []Server                   `yaml:"servers,omitempty" json:"servers,omitempty"`
-	Security          []SecurityRequirement      `yaml:"security,omitempty" json:"security,omitempty"`
-	Tags              []Tag                      `yaml:"tags,omitempty" json:"tags,omitempty"`
+	source            *yaml.Node
+	Extensions        Extensions            `yaml:",inline" json:"-"`
+	OpenAPI           string                `yaml:"openapi" json:"openapi"`
+	JSONSchemaDialect string                `yaml:"jsonSchemaDialect,omitempty" json:"jsonSchemaDialect,omitempty"`
+	Servers           []Server              `yaml:"servers,omitempty" json:"servers,omitempty"`
+	Security          []SecurityRequirement `yaml:"security,omitempty" json:"security,omitempty"`
+	Tags              []Tag                 `yaml:"tags,omitempty" json:"tags,omitempty"`
 }
 
 type Info struct {
@@ -0,0 +1,711 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	zodUnknown   = "z.unknown()"
+	zodUndefined = "z.undefined()"
+	zodNull      = "z.null()"
+)
+
+var zodFormatTypes = map[string]string{
+	"Blob":    "z.instanceof(Blob)",
+	"Date":    "z.coerce.date()",
+	"bigint":  "z.coerce.bigint()",
+	"boolean": "z.boolean()",
+}
+
+type zodContext struct {
+	doc     *Document
+	types   *enumContext
+	names   map[string]string
+	defined map[string]bool
+	lazy    bool
+}
+
+func EmitZodFromDocument(doc *Document) (string, error) {
+	return EmitZodFromDocumentAt(doc, time.Now(), "", "")
+}
+
+func EmitZodFromDocumentAt(doc *Document, generatedAt time.Time, cliVersion, openAPIVersion string) (string, error) {
+	return EmitZodFromDocumentWith(doc, generatedAt, cliVersion, openAPIVersion, IROptions{})
+}
+
+func EmitZodFromDocumentWith(doc *Document, generatedAt time.Time, cliVersion, openAPIVersion string, opts IROptions) (string, error) {
+	if doc == nil {
+		return "", ErrNilDoc
+	}
+
+	var b strings.Builder
+
+	generator := "openapi-tsgen"
+	if cliVersion != "" {
+		generator = generator + "@" + cliVersion
+	}
+
+	b.WriteString(GeneratedHeader(generator, openAPIVersion, generatedAt))
+	b.WriteString("import { z } from \"zod\";\n\n")
+
+	ctx := newZodContext(doc, opts)
+	writeZodComponents(&b, ctx)
+	if err := writeZodPathItems(&b, ctx, "routes", "paths", doc.Paths); err != nil {
+		return "", err
+	}
+	if err := writeZodPathItems(&b, ctx, "webhooks", "webhooks", doc.Webhooks); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func newZodContext(doc *Document, opts IROptions) *zodContext {
+	types := newEnumContext(nil)
+	types.diags = newDiagnostics()
+	types.access = map[string]bool{}
+	types.configure(doc, opts, map[string]IRBrand{})
+	ctx := &zodContext{
+		doc:     doc,
+		types:   types,
+		names:   map[string]string{},
+		defined: map[string]bool{},
+	}
+	if doc.Components == nil {
+		return ctx
+	}
+	used := map[string]bool{"z": true, "schemas": true, "routes": true, "webhooks": true}
+	for _, k := range sortedSchemaNames(doc.Components.Schemas) {
+		base := sanitizeIdent(k) + "Schema"
+		name := base
+		for i := 2; used[name]; i++ {
+			name = base + strconv.Itoa(i)
+		}
+		used[name] = true
+		ctx.names[k] = name
+	}
+	return ctx
+}
+
+func sortedSchemaNames(schemas map[string]Schema) []string {
+	keys := make([]string, 0, len(schemas))
+	for k := range schemas {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeZodComponents(b *strings.Builder, ctx *zodContext) {
+	if ctx.doc.Components == nil || len(ctx.doc.Components.Schemas) == 0 {
+		return
+	}
+
+	types := map[string]*TypeNode{}
+	for _, k := range sortedSchemaNames(ctx.doc.Components.Schemas) {
+		sch := ctx.doc.Components.Schemas[k]
+		leave := ctx.types.enterAt("components", "schemas", k)
+		types[k] = componentSchemaToType(ctx.doc, k, &sch, 0, ctx.types, modeDefault)
+		leave()
+	}
+
+	for _, k := range zodComponentOrder(types) {
+		ctx.lazy = false
+		ts := zodNode(ctx, types[k])
+		ctx.defined[k] = true
+		decl := "export const " + ctx.names[k]
+		if ctx.lazy {
+			decl += ": z.ZodTypeAny"
+		}
+		b.WriteString(decl + " = " + ts + ";\n\n")
+	}
+
+	b.WriteString("export const schemas = {\n")
+	for _, k := range sortedSchemaNames(ctx.doc.Components.Schemas) {
+		b.WriteString("  " + safeTSKey(k) + ": " + ctx.names[k] + ",\n")
+	}
+	b.WriteString("};\n\n")
+}
+
+func zodComponentOrder(types map[string]*TypeNode) []string {
+	names := make([]string, 0, len(types))
+	for k := range types {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	order := make([]string, 0, len(types))
+	state := map[string]int{}
+	var visit func(name string)
+	visit = func(name string) {
+		if state[name] != 0 {
+			return
+		}
+		state[name] = 1
+		for _, dep := range schemaRefNames(types[name]) {
+			if _, ok := types[dep]; ok {
+				visit(dep)
+			}
+		}
+		state[name] = 2
+		order = append(order, name)
+	}
+	for _, k := range names {
+		visit(k)
+	}
+	return order
+}
+
+func schemaRefNames(t *TypeNode) []string {
+	seen := map[string]bool{}
+	var walk func(t *TypeNode)
+	walk = func(t *TypeNode) {
+		if t == nil {
+			return
+		}
+		if t.Kind == TypeRef && t.Section == "schemas" {
+			seen[t.Name] = true
+		}
+		walk(t.Elem)
+		walk(t.Key)
+		for _, it := range t.Items {
+			walk(it)
+		}
+		for _, f := range t.Fields {
+			walk(f.Type)
+		}
+	}
+	walk(t)
+	out := make([]string, 0, len(seen))
+	for k := range seen {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func zodSchemaToTS(ctx *zodContext, s *RefOr[Schema], mode schemaMode) string {
+	return zodNode(ctx, schemaToType(ctx.doc, s, 0, ctx.types, "", mode))
+}
+
+func zodNode(ctx *zodContext, t *TypeNode) string {
+	if t == nil {
+		return zodUnknown
+	}
+	switch t.Kind {
+	case TypeNever:
+		return "z.never()"
+	case TypePrimitive:
+		return zodPrimitive(t)
+	case TypeLiteral:
+		return "z.literal(" + literalToTS(t.Literal) + ")"
+	case TypeTemplate:
+		return zodTemplate(t)
+	case TypeEnum:
+		return zodEnum(ctx.types.enums[t.Name])
+	case TypeBrand:
+		return zodBrand(ctx.types.brands[t.Name])
+	case TypeArray:
+		return "z.array(" + zodNode(ctx, t.Elem) + ")" + zodArrayBounds(t.Schema)
+	case TypeTuple:
+		return zodTuple(ctx, t)
+	case TypeObject:
+		return zodObject(ctx, t)
+	case TypeRecord:
+		if t.Elem != nil && t.Elem.Kind == TypeNever {
+			return "z.object({}).strict()"
+		}
+		return "z.record(" + zodNode(ctx, t.Key) + ", " + zodNode(ctx, t.Elem) + ")"
+	case TypeMapped:
+		return "z.record(" + zodNode(ctx, t.Key) + ", " + zodNode(ctx, t.Elem) + ")"
+	case TypeUnion:
+		return zodUnionNode(ctx, t.Items)
+	case TypeIntersection:
+		return zodIntersection(ctx, t.Items)
+	case TypeOmit, TypeOptional:
+		return zodNode(ctx, t.Elem)
+	case TypeRef:
+		return zodRef(ctx, t)
+	default:
+		return zodUnknown
+	}
+}
+
+func zodPrimitive(t *TypeNode) string {
+	switch t.Name {
+	case schemaTypeString:
+		return zodString(t.Schema)
+	case "number":
+		return zodNumber(t.Schema, t.Schema["type"] == "integer")
+	case schemaTypeNull:
+		return zodNull
+	}
+	if out, ok := zodFormatTypes[t.Name]; ok {
+		return out
+	}
+	return "z.custom<" + t.Name + ">()"
+}
+
+func zodTemplate(t *TypeNode) string {
+	if isPrimitive(t.Elem, "number") {
+		return "z.string().regex(new RegExp(" + strconv.Quote("^"+regexp.QuoteMeta(t.Name)+`-?\d+(\.\d+)?$`) + "))"
+	}
+	if t.Name == "" {
+		return "z.string()"
+	}
+	return "z.string().startsWith(" + strconv.Quote(t.Name) + ")"
+}
+
+func zodEnum(e IREnum) string {
+	if len(e.Members) == 0 {
+		return zodUnknown
+	}
+	allStrings := true
+	lits := make([]string, 0, len(e.Members))
+	for _, m := range e.Members {
+		if _, ok := m.Value.(string); !ok {
+			allStrings = false
+		}
+		lits = append(lits, literalToTS(m.Value))
+	}
+	if allStrings {
+		return "z.enum([" + strings.Join(lits, ", ") + "])"
+	}
+	parts := make([]string, 0, len(lits))
+	for _, lit := range lits {
+		parts = append(parts, "z.literal("+lit+")")
+	}
+	return zodUnion(parts)
+}
+
+func zodBrand(br IRBrand) string {
+	if br.Name == "" {
+		return zodUnknown
+	}
+	return "z.custom<" + br.Base + " & { readonly __brand: " + strconv.Quote(br.Name) + " }>((value) => " + strings.Join(brandChecks(br), " && ") + ")"
+}
+
+func zodTuple(ctx *zodContext, t *TypeNode) string {
+	items := t.Items
+	rest := ""
+	if n := len(items); n > 0 && items[n-1].Kind == TypeRest {
+		rest = zodNode(ctx, items[n-1].Elem.Elem)
+		items = items[:n-1]
+	}
+
+	parts := make([]string, 0, len(items))
+	required := len(items)
+	for i, it := range items {
+		if it.Kind == TypeOptional && required == len(items) {
+			required = i
+		}
+		parts = append(parts, zodNode(ctx, it))
+	}
+	variants := make([]string, 0, len(items)-required+1)
+	for n := required; n <= len(items); n++ {
+		variants = append(variants, "z.tuple(["+strings.Join(parts[:n], ", ")+"])")
+	}
+	if rest != "" {
+		variants[len(variants)-1] += ".rest(" + rest + ")"
+	}
+	return zodUnion(variants)
+}
+
+func zodArrayBounds(o map[string]any) string {
+	out := ""
+	if n, ok := zodNumberLiteral(o["minItems"]); ok {
+		out += ".min(" + n + ")"
+	}
+	if n, ok := zodNumberLiteral(o["maxItems"]); ok {
+		out += ".max(" + n + ")"
+	}
+	return out
+}
+
+func zodObject(ctx *zodContext, t *TypeNode) string {
+	fields := make([]zodField, 0, len(t.Fields))
+	for _, f := range t.Fields {
+		fields = append(fields, zodField{Name: f.Name, Schema: zodNode(ctx, f.Type), Optional: f.Optional})
+	}
+	out := zodObjectFromFields(fields)
+	if t.Schema["additionalProperties"] == false {
+		out += ".strict()"
+	}
+	return out
+}
+
+func zodUnionNode(ctx *zodContext, items []*TypeNode) string {
+	nullable := false
+	parts := make([]string, 0, len(items))
+	for _, it := range items {
+		if isPrimitive(it, schemaTypeNull) {
+			nullable = true
+			continue
+		}
+		parts = append(parts, zodNode(ctx, it))
+	}
+	if len(parts) == 0 {
+		return zodNull
+	}
+	out := zodUnion(parts)
+	if nullable && out != zodUnknown {
+		out += ".nullable()"
+	}
+	return out
+}
+
+func zodIntersection(ctx *zodContext, items []*TypeNode) string {
+	if len(items) == 0 {
+		return zodUnknown
+	}
+	if len(items) == 2 && items[0].Kind == TypeObject && items[1].Kind == TypeRecord && isPrimitive(items[1].Key, schemaTypeString) {
+		return zodObject(ctx, items[0]) + ".catchall(" + zodNode(ctx, items[1].Elem) + ")"
+	}
+	out := zodNode(ctx, items[0])
+	for _, it := range items[1:] {
+		out = "z.intersection(" + out + ", " + zodNode(ctx, it) + ")"
+	}
+	return out
+}
+
+func zodRef(ctx *zodContext, t *TypeNode) string {
+	name, ok := ctx.names[t.Name]
+	if !ok || t.Section != "schemas" {
+		return zodUnknown
+	}
+	if ctx.defined[t.Name] {
+		return name
+	}
+	ctx.lazy = true
+	return "z.lazy(() => " + name + ")"
+}
+
+func zodUnion(parts []string) string {
+	seen := map[string]bool{}
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p == zodUnknown {
+			return zodUnknown
+		}
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	switch len(out) {
+	case 0:
+		return zodUnknown
+	case 1:
+		return out[0]
+	}
+	return "z.union([" + strings.Join(out, ", ") + "])"
+}
+
+func zodString(o map[string]any) string {
+	out := "z.string()"
+	switch o["format"] {
+	case "email":
+		out += ".email()"
+	case "uuid":
+		out += ".uuid()"
+	case "uri", "url":
+		out += ".url()"
+	case "date-time":
+		out += ".datetime({ offset: true })"
+	}
+	if n, ok := zodNumberLiteral(o["minLength"]); ok {
+		out += ".min(" + n + ")"
+	}
+	if n, ok := zodNumberLiteral(o["maxLength"]); ok {
+		out += ".max(" + n + ")"
+	}
+	if p, ok := o["pattern"].(string); ok && p != "" {
+		out += ".regex(new RegExp(" + strconv.Quote(p) + "))"
+	}
+	return out
+}
+
+func zodNumber(o map[string]any, integer bool) string {
+	out := "z.number()"
+	if integer {
+		out += ".int()"
+	}
+	if n, ok := zodNumberLiteral(o["minimum"]); ok {
+		if b, ok := o["exclusiveMinimum"].(bool); ok && b {
+			out += ".gt(" + n + ")"
+		} else {
+			out += ".gte(" + n + ")"
+		}
+	}
+	if n, ok := zodNumberLiteral(o["exclusiveMinimum"]); ok {
+		out += ".gt(" + n + ")"
+	}
+	if n, ok := zodNumberLiteral(o["maximum"]); ok {
+		if b, ok := o["exclusiveMaximum"].(bool); ok && b {
+			out += ".lt(" + n + ")"
+		} else {
+			out += ".lte(" + n + ")"
+		}
+	}
+	if n, ok := zodNumberLiteral(o["exclusiveMaximum"]); ok {
+		out += ".lt(" + n + ")"
+	}
+	if n, ok := zodNumberLiteral(o["multipleOf"]); ok {
+		out += ".multipleOf(" + n + ")"
+	}
+	return out
+}
+
+func zodNumberLiteral(v any) (string, bool) {
+	switch v.(type) {
+	case int, int64, int32, float64, float32:
+		return literalToTS(v), true
+	}
+	return "", false
+}
+
+type zodField struct {
+	Name     string
+	Schema   string
+	Optional bool
+}
+
+func zodObjectFromFields(fields []zodField) string {
+	if len(fields) == 0 {
+		return "z.object({})"
+	}
+	var b strings.Builder
+	b.WriteString("z.object({\n")
+	for _, f := range fields {
+		value := f.Schema
+		if f.Optional {
+			value += ".optional()"
+		}
+		writeZodField(&b, "  ", safeProp(f.Name), value)
+	}
+	b.WriteString("})")
+	return b.String()
+}
+
+func writeZodField(b *strings.Builder, indent, key, value string) {
+	lines := strings.Split(value, "\n")
+	b.WriteString(indent + key + ": " + lines[0])
+	for _, line := range lines[1:] {
+		b.WriteString("\n" + indent + line)
+	}
+	b.WriteString(",\n")
+}
+
+func writeZodPathItems(b *strings.Builder, ctx *zodContext, label, section string, items map[string]RefOr[PathItem]) error {
+	if len(items) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var body strings.Builder
+	for _, key := range keys {
+		pi, err := resolvePathItem(ctx.doc, items[key])
+		if err != nil {
+			return fmt.Errorf("%s %q: %w", label, key, err)
+		}
+		if pi == nil {
+			continue
+		}
+		ops, err := zodPathItemOps(ctx, []string{section, key}, pi)
+		if err != nil {
+			return fmt.Errorf("%s %q: %w", label, key, err)
+		}
+		if len(ops) == 0 {
+			continue
+		}
+		methods := make([]string, 0, len(ops))
+		for m := range ops {
+			methods = append(methods, m)
+		}
+		sort.Strings(methods)
+
+		body.WriteString("  " + strconv.Quote(key) + ": {\n")
+		for _, m := range methods {
+			writeZodField(&body, "    ", m, ops[m])
+		}
+		body.WriteString("  },\n")
+	}
+	if body.Len() == 0 {
+		return nil
+	}
+	b.WriteString("export const " + label + " = {\n")
+	b.WriteString(body.String())
+	b.WriteString("};\n\n")
+	return nil
+}
+
+func zodPathItemOps(ctx *zodContext, item []string, pi *PathItem) (map[string]string, error) {
+	ops := map[string]string{}
+	methods := []struct {
+		op   *Operation
+		name string
+	}{
+		{op: pi.Get, name: "get"},
+		{op: pi.Post, name: "post"},
+		{op: pi.Put, name: "put"},
+		{op: pi.Patch, name: "patch"},
+		{op: pi.Delete, name: "delete"},
+		{op: pi.Options, name: "options"},
+		{op: pi.Head, name: "head"},
+		{op: pi.Trace, name: "trace"},
+	}
+	for _, m := range methods {
+		if m.op == nil {
+			continue
+		}
+		zs, err := zodOperation(ctx, item, m.name, pi, m.op)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.name, err)
+		}
+		ops[m.name] = zs
+	}
+	return ops, nil
+}
+
+func zodOperation(ctx *zodContext, item []string, method string, pi *PathItem, op *Operation) (string, error) {
+	type zodParam struct {
+		p      *Parameter
+		schema string
+	}
+	params := map[paramKey]zodParam{}
+	for _, list := range []struct {
+		at     []string
+		params []RefOr[Parameter]
+	}{
+		{at: item, params: pi.Parameters},
+		{at: append(append([]string(nil), item...), method), params: op.Parameters},
+	} {
+		for i := range list.params {
+			p, err := resolveParameter(ctx.doc, list.params[i])
+			if err != nil {
+				return "", fmt.Errorf("params: %w", err)
+			}
+			if p != nil {
+				leave := ctx.types.enterAt(append(append([]string(nil), list.at...), "parameters", strconv.Itoa(i))...)
+				params[paramKey{Name: p.Name, In: p.In}] = zodParam{p: p, schema: zodParameter(ctx, p)}
+				leave()
+			}
+		}
+	}
+	defer ctx.types.enterAt(append(append([]string(nil), item...), method)...)()
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, loc := range []struct{ in, label string }{
+		{in: "path", label: "params"},
+		{in: "query", label: "query"},
+		{in: "header", label: "headers"},
+		{in: "cookie", label: "cookies"},
+	} {
+		fields := []zodField{}
+		for k, p := range params {
+			if k.In != loc.in {
+				continue
+			}
+			fields = append(fields, zodField{
+				Name:     k.Name,
+				Schema:   p.schema,
+				Optional: !p.p.Required && p.p.In != "path",
+			})
+		}
+		sort.Slice(fields, func(i, j int) bool {
+			return fields[i].Name < fields[j].Name
+		})
+		if len(fields) > 0 {
+			writeZodField(&b, "  ", loc.label, zodObjectFromFields(fields))
+		}
+	}
+
+	if op.RequestBody != nil {
+		rb, err := resolveRequestBody(ctx.doc, *op.RequestBody)
+		if err != nil {
+			return "", fmt.Errorf("requestBody: %w", err)
+		}
+		if rb != nil {
+			leave := ctx.types.enter("requestBody")
+			writeZodField(&b, "  ", "requestBody", zodContent(ctx, rb.Content, zodUnknown, modeInput))
+			leave()
+		}
+	}
+
+	codes := make([]string, 0, len(op.Responses))
+	for c := range op.Responses {
+		codes = append(codes, c)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		ai, aok := parseStatusCode(codes[i])
+		bi, bok := parseStatusCode(codes[j])
+		if aok && bok {
+			return ai < bi
+		}
+		if aok != bok {
+			return aok
+		}
+		return codes[i] < codes[j]
+	})
+	b.WriteString("  responses: {\n")
+	for _, c := range codes {
+		resp, err := resolveResponse(ctx.doc, op.Responses[c])
+		if err != nil {
+			return "", fmt.Errorf("responses: %w", err)
+		}
+		zs := zodUndefined
+		if resp != nil {
+			leave := ctx.types.enter("responses", c)
+			zs = zodContent(ctx, resp.Content, zodUndefined, modeOutput)
+			leave()
+		}
+		key := c
+		if _, ok := parseStatusCode(c); !ok && c != "default" {
+			key = strconv.Quote(c)
+		}
+		writeZodField(&b, "    ", key, zs)
+	}
+	b.WriteString("  },\n")
+	b.WriteString("}")
+	return b.String(), nil
+}
+
+func zodParameter(ctx *zodContext, p *Parameter) string {
+	if p.Schema != nil {
+		defer ctx.types.enter("schema")()
+		return zodSchemaToTS(ctx, p.Schema, modeInput)
+	}
+	return zodContent(ctx, p.Content, zodUnknown, modeInput)
+}
+
+func zodContent(ctx *zodContext, content map[string]MediaType, empty string, mode schemaMode) string {
+	if len(content) == 0 {
+		return empty
+	}
+	keys := make([]string, 0, len(content))
+	for k := range content {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		mt := content[k]
+		if mt.Schema == nil {
+			parts = append(parts, zodUnknown)
+			continue
+		}
+		leave := ctx.types.enter("content", k, "schema")
+		parts = append(parts, zodSchemaToTS(ctx, mt.Schema, mode))
+		leave()
+	}
+	return zodUnion(parts)
+}
@@ -22,3 +22,8 @@ for fixture in "$fixtures_dir"/*.fixture.json; do
   out="$snapshots_dir/$base.json.snapshot.ts"
   go run . -s "$fixture" --input-json -o "$out"
 done
+
+go run . -s "$fixtures_dir/client.fixture.yml" -o "$snapshots_dir/client.yml.snapshot.ts" \
+  --client "$snapshots_dir/client.fetch-client.snapshot.ts"
+go run . -s "$fixtures_dir/zod.fixture.yml" -o "$snapshots_dir/zod.yml.snapshot.ts" \
+  --zod "$snapshots_dir/zod.zod.snapshot.ts"
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+const brandsSpec = `openapi: 3.0.3
+info:
+  title: Brands
+  version: "1.0.0"
+paths:
+  /users/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            $ref: "#/components/schemas/UserId"
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/User"
+components:
+  schemas:
+    UserId:
+      type: string
+      format: uuid
+    OrderId:
+      type: string
+      format: uuid
+    Age:
+      type: integer
+      minimum: 0
+      exclusiveMaximum: true
+      maximum: 150
+    Slug:
+      type: string
+      pattern: "^[a-z0-9-]+$"
+      minLength: 1
+      maxLength: 64
+      nullable: true
+    Name:
+      type: string
+    User:
+      type: object
+      required: [id]
+      properties:
+        id:
+          $ref: "#/components/schemas/UserId"
+        age:
+          $ref: "#/components/schemas/Age"
+        sku:
+          type: string
+          x-ts-brand: Sku
+        price:
+          type: number
+          multipleOf: 0.01
+`
+
+func TestBrandedTypes(t *testing.T) {
+	res, err := schema.NewGenerator(schema.Options{Brands: true}).Generate(strings.NewReader(brandsSpec))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	for _, want := range []string{
+		"export type UserId = string & { readonly __brand: \"UserId\" };\n\n" +
+			"export function isUserId(value: unknown): value is UserId {\n" +
+			"  return typeof value === \"string\" && /^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$/i.test(value);\n" +
+			"}\n",
+		"export type OrderId = string & { readonly __brand: \"OrderId\" };",
+		"  return typeof value === \"number\" && Number.isInteger(value) && value >= 0 && value < 150;\n",
+		"  return typeof value === \"string\" && new RegExp(\"^[a-z0-9-]+$\").test(value) && value.length >= 1 && value.length <= 64;\n",
+		"export function isSku(value: unknown): value is Sku {\n  return typeof value === \"string\";\n}\n",
+		"    Slug: (Slug | null);\n",
+		"    Name: string;\n",
+		"        id: UserId;\n",
+		"          sku?: Sku;\n",
+		"          price?: number;\n",
+	} {
+		if !strings.Contains(res.Types, want) {
+			t.Errorf("missing %q in:\n%s", want, res.Types)
+		}
+	}
+}
+
+func TestBrandsAreOptIn(t *testing.T) {
+	res, err := schema.NewGenerator(schema.Options{}).Generate(strings.NewReader(brandsSpec))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if strings.Contains(res.Types, "__brand") {
+		t.Fatalf("brands emitted without Brands:\n%s", res.Types)
+	}
+}
+
+func TestZodBrandedTypes(t *testing.T) {
+	res, err := schema.NewGenerator(schema.Options{Zod: true, Brands: true}).Generate(strings.NewReader(brandsSpec))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	for _, want := range []string{
+		"export const AgeSchema = z.custom<number & { readonly __brand: \"Age\" }>((value) => typeof value === \"number\" && Number.isInteger(value) && value >= 0 && value < 150);\n",
+		"export const SlugSchema = z.custom<string & { readonly __brand: \"Slug\" }>((value) => typeof value === \"string\" && new RegExp(\"^[a-z0-9-]+$\").test(value) && value.length >= 1 && value.length <= 64).nullable();\n",
+		"export const NameSchema = z.string();\n",
+		"  sku: z.custom<string & { readonly __brand: \"Sku\" }>((value) => typeof value === \"string\").optional(),\n",
+		"      params: z.object({\n        id: UserIdSchema,\n      }),\n",
+	} {
+		if !strings.Contains(res.Zod, want) {
+			t.Errorf("missing %q in:\n%s", want, res.Zod)
+		}
+	}
+}
+
+func TestBrandedComponentsSkipAliases(t *testing.T) {
+	res, err := schema.NewGenerator(schema.Options{Brands: true, ComponentAliases: true}).Generate(strings.NewReader(brandsSpec))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	for _, name := range []string{"UserId", "OrderId", "Age", "Slug"} {
+		if strings.Contains(res.Types, "export type "+name+"2 ") {
+			t.Fatalf("branded component %s got a renamed alias:\n%s", name, res.Types)
+		}
+		if !strings.Contains(res.Types, "export type "+name+" = ") {
+			t.Fatalf("missing brand %s:\n%s", name, res.Types)
+		}
+	}
+	for _, want := range []string{
+		"export type Name = Components[\"schemas\"][\"Name\"];\n",
+		"export type User = Components[\"schemas\"][\"User\"];\n",
+	} {
+		if !strings.Contains(res.Types, want) {
+			t.Fatalf("missing:\n%s\nin:\n%s", want, res.Types)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+const callbackRefSpec = `openapi: 3.1.0
+info:
+  title: Callbacks
+  version: "1.0.0"
+paths:
+  /subscribe:
+    post:
+      responses:
+        "201":
+          description: created
+      callbacks:
+        event:
+          $ref: "#/components/callbacks/%s"
+components:
+  callbacks:
+    Event:
+      "{$request.query.url}":
+        post:
+          responses:
+            "200":
+              description: ok
+          callbacks:
+            again:
+              $ref: "#/components/callbacks/Event"
+`
+
+func TestCallbackRefErrors(t *testing.T) {
+	for _, tc := range []struct {
+		want error
+		name string
+	}{
+		{schema.ErrMissingComponentCallback, "Missing"},
+		{schema.ErrCallbackCycle, "Event"},
+	} {
+		spec := strings.Replace(callbackRefSpec, "%s", tc.name, 1)
+		_, err := schema.NewGenerator(schema.Options{}).Generate(strings.NewReader(spec))
+		if !errors.Is(err, tc.want) {
+			t.Fatalf("%s: err = %v, want %v", tc.name, err, tc.want)
+		}
+	}
+}
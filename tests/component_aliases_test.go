@@ -0,0 +1,174 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+const componentAliasesSpec = `openapi: 3.1.0
+info:
+  title: Aliases
+  version: "1.0.0"
+paths:
+  /pets:
+    get:
+      operationId: pet
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Pet:
+      description: A pet.
+      type: object
+      properties:
+        status:
+          type: string
+          enum: [available, sold]
+    PetStatusEnum:
+      type: string
+    pet-store:
+      type: object
+    Operations:
+      type: string
+  responses:
+    Pet:
+      description: pet response
+  parameters:
+    limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+`
+
+func TestComponentAliases(t *testing.T) {
+	res, err := schema.NewGenerator(schema.Options{
+		ComponentAliases: true,
+		OperationAliases: true,
+	}).Generate(strings.NewReader(componentAliasesSpec))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	want := "export type Operations2 = Components[\"schemas\"][\"Operations\"];\n" +
+		"/** @description A pet. */\n" +
+		"export type Pet = Components[\"schemas\"][\"Pet\"];\n" +
+		"export type PetStatusEnum2 = Components[\"schemas\"][\"PetStatusEnum\"];\n" +
+		"export type PetStore = Components[\"schemas\"][\"pet-store\"];\n" +
+		"/** @description pet response */\n" +
+		"export type PetResponse = Components[\"responses\"][\"Pet\"];\n" +
+		"export type limitParameter = Components[\"parameters\"][\"limit\"];\n"
+	if !strings.Contains(res.Types, want) {
+		t.Fatalf("missing:\n%s\nin:\n%s", want, res.Types)
+	}
+	if !strings.Contains(res.Types, "export type Pet2Response = Routes[\"/pets\"][\"get\"][\"responses\"][200];") {
+		t.Fatalf("operation alias did not avoid component alias:\n%s", res.Types)
+	}
+}
+
+func TestComponentAliasPrefixSuffix(t *testing.T) {
+	res, err := schema.NewGenerator(schema.Options{
+		ComponentAliases:     true,
+		ComponentAliasPrefix: "Api",
+		ComponentAliasSuffix: "Dto",
+	}).Generate(strings.NewReader(componentAliasesSpec))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	for _, want := range []string{
+		"export type ApiPetDto = Components[\"schemas\"][\"Pet\"];\n",
+		"export type ApiPetResponseDto = Components[\"responses\"][\"Pet\"];\n",
+		"export type ApiOperationsDto = Components[\"schemas\"][\"Operations\"];\n",
+	} {
+		if !strings.Contains(res.Types, want) {
+			t.Fatalf("missing:\n%s\nin:\n%s", want, res.Types)
+		}
+	}
+}
+
+func TestComponentAliasesAvoidReservedNames(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Reserved
+  version: "1.0.0"
+paths: {}
+components:
+  schemas:
+    default:
+      type: string
+    string:
+      type: string
+    Record:
+      type: object
+      additionalProperties:
+        type: string
+    RecordSchema:
+      type: integer
+`
+	res, err := schema.NewGenerator(schema.Options{ComponentAliases: true}).Generate(strings.NewReader(spec))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	for _, want := range []string{
+		"export type RecordSchema = Components[\"schemas\"][\"Record\"];\n",
+		"export type RecordSchema2 = Components[\"schemas\"][\"RecordSchema\"];\n",
+		"export type defaultSchema = Components[\"schemas\"][\"default\"];\n",
+		"export type stringSchema = Components[\"schemas\"][\"string\"];\n",
+	} {
+		if !strings.Contains(res.Types, want) {
+			t.Fatalf("missing:\n%s\nin:\n%s", want, res.Types)
+		}
+	}
+	for _, bad := range []string{"export type Record =", "export type default =", "export type string ="} {
+		if strings.Contains(res.Types, bad) {
+			t.Fatalf("unexpected %q in:\n%s", bad, res.Types)
+		}
+	}
+}
+
+func TestComponentAliasesPrefixLeadingDigits(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Digits
+  version: "1.0.0"
+paths: {}
+components:
+  schemas:
+    2fa-settings:
+      type: object
+  responses:
+    "404":
+      description: not found
+`
+	for _, tc := range []struct {
+		prefix string
+		want   []string
+	}{
+		{want: []string{
+			"export type Component2faSettings = Components[\"schemas\"][\"2fa-settings\"];\n",
+			"export type Component404Response = Components[\"responses\"][\"404\"];\n",
+		}},
+		{prefix: "Api", want: []string{
+			"export type Api2faSettings = Components[\"schemas\"][\"2fa-settings\"];\n",
+			"export type Api404Response = Components[\"responses\"][\"404\"];\n",
+		}},
+	} {
+		res, err := schema.NewGenerator(schema.Options{
+			ComponentAliases:     true,
+			ComponentAliasPrefix: tc.prefix,
+		}).Generate(strings.NewReader(spec))
+		if err != nil {
+			t.Fatalf("generate: %v", err)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(res.Types, want) {
+				t.Fatalf("missing:\n%s\nin:\n%s", want, res.Types)
+			}
+		}
+	}
+}
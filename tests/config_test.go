@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/brownhounds/openapi-tsgen/config"
+)
+
+func TestConfigDiscoverAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	path, err := config.Discover(dir)
+	if err != nil || path != "" {
+		t.Fatalf("expected no config, got %q (%v)", path, err)
+	}
+
+	writeFile(t, filepath.Join(dir, "openapi-tsgen.yml"), `targets:
+  - name: petstore
+    input: specs/petstore.yml
+    output: gen/petstore.ts
+    client: gen/petstore.client.ts
+  - input: specs/users.json
+    inputJson: true
+    output: gen/users.ts
+    zod: gen/users.zod.ts
+    formats:
+      int64: bigint
+      date-time: Date
+`)
+
+	path, err = config.Discover(dir)
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	if path != filepath.Join(dir, "openapi-tsgen.yml") {
+		t.Fatalf("unexpected config path %q", path)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(cfg.Targets) != 2 {
+		t.Fatalf("expected 2 targets, got %d", len(cfg.Targets))
+	}
+	pet := cfg.Targets[0]
+	if pet.Name != "petstore" || pet.Input != filepath.Join(dir, "specs/petstore.yml") || pet.Client != filepath.Join(dir, "gen/petstore.client.ts") {
+		t.Fatalf("unexpected petstore target: %#v", pet)
+	}
+	users := cfg.Targets[1]
+	if users.Name != "specs/users.json" || !users.InputJSON || users.Zod != filepath.Join(dir, "gen/users.zod.ts") ||
+		users.Formats["int64"] != "bigint" || users.Formats["date-time"] != "Date" {
+		t.Fatalf("unexpected users target: %#v", users)
+	}
+}
+
+func TestConfigLoadRejectsInvalidTargets(t *testing.T) {
+	dir := t.TempDir()
+	cases := []struct {
+		want    error
+		name    string
+		content string
+	}{
+		{name: "empty", content: "targets: []\n", want: config.ErrNoTargets},
+		{name: "input", content: "targets:\n  - output: a.ts\n", want: config.ErrTargetInput},
+		{name: "output", content: "targets:\n  - input: a.yml\n", want: config.ErrTargetOutput},
+		{name: "duplicate", content: "targets:\n  - {input: a.yml, output: a.ts}\n  - {input: a.yml, output: b.ts}\n", want: config.ErrDuplicateName},
+		{name: "stdout", content: "targets:\n  - {input: a.yml, output: \"-\"}\n  - {input: b.yml, output: b.ts, zod: \"-\"}\n", want: config.ErrSharedStdout},
+	}
+	for _, tc := range cases {
+		path := filepath.Join(dir, tc.name+".yaml")
+		writeFile(t, path, tc.content)
+		if _, err := config.Load(path); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	path := filepath.Join(dir, "unknown.yaml")
+	writeFile(t, path, "targets:\n  - {input: a.yml, output: a.ts, outptu: b.ts}\n")
+	if _, err := config.Load(path); err == nil {
+		t.Fatal("expected unknown key to be rejected")
+	}
+}
+
+func TestConfigLoadKeepsStdioPaths(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "openapi-tsgen.yaml")
+	writeFile(t, path, "targets:\n  - {input: \"-\", output: \"-\"}\n  - {input: b.yml, output: b.ts}\n")
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got := cfg.Targets[0]; got.Input != "-" || got.Output != "-" {
+		t.Fatalf("stdio paths resolved against the config dir: %#v", got)
+	}
+}
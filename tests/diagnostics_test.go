@@ -0,0 +1,214 @@
+package tests
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+func TestToIRRecordsFallbackDiagnostics(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "openapi.yml")
+
+	deep := "type: string\n"
+	for range 32 {
+		deep = "type: array\nitems:\n" + indent(deep)
+	}
+	writeFile(t, path, `openapi: 3.1.0
+info:
+  title: Diagnostics
+  version: "1.0.0"
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          headers:
+            X-Rate:
+              $ref: "#/components/headers/Missing"
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/examples/Pet"
+components:
+  schemas:
+    Odd:
+      type: object
+      properties:
+        when:
+          type: date
+    Deep:
+`+indent(indent(indent(deep))))
+
+	doc, err := schema.LoadDocument(path, schema.InputYAML)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	ir, err := schema.ToIR(doc)
+	if err != nil {
+		t.Fatalf("ir: %v", err)
+	}
+
+	got := map[string]string{}
+	for _, d := range ir.Diagnostics {
+		got[d.Pointer] = d.Reason
+	}
+	want := map[string]string{
+		"#/components/schemas/Odd/properties/when":                          `unsupported type "date"`,
+		"#/paths/~1pets/get/responses/200/headers/X-Rate":                   "unresolvable response header",
+		"#/paths/~1pets/get/responses/200/content/application~1json/schema": `unsupported $ref "#/components/examples/Pet"`,
+		"#/components/schemas/Deep" + strings.Repeat("/items", 16):          "schema nested deeper than 30 levels",
+	}
+	for ptr, reason := range want {
+		if !strings.Contains(got[ptr], reason) {
+			t.Errorf("expected %s: %s, got %q", ptr, reason, got[ptr])
+		}
+	}
+	if len(ir.Diagnostics) != len(want) {
+		t.Fatalf("expected %d diagnostics, got %v", len(want), ir.Diagnostics)
+	}
+}
+
+const recursiveSpec = `openapi: 3.1.0
+info:
+  title: Tree
+  version: "1.0.0"
+paths:
+  /trees:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Tree"
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Tree"
+components:
+  schemas:
+    Tree:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+          readOnly: true
+        children:
+          type: array
+          items:
+            $ref: "#/components/schemas/Tree"
+`
+
+func TestRecursiveSchemaPassesStrict(t *testing.T) {
+	res, err := schema.NewGenerator(schema.Options{Zod: true}).Generate(strings.NewReader(recursiveSpec))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if len(res.Diagnostics) != 0 {
+		t.Fatalf("recursive schema would fail --strict: %v", res.Diagnostics)
+	}
+	want := "      requestBody: {\n        children?: Components[\"schemas\"][\"Tree\"][];\n      };"
+	if !strings.Contains(res.Types, want) {
+		t.Fatalf("expected the recursive ref to stay a reference:\n%s", res.Types)
+	}
+}
+
+func TestDepthDiagnosticPointsAtInliningRoute(t *testing.T) {
+	nested := "$ref: \"#/components/schemas/Nested\"\n"
+	for range 8 {
+		nested = "type: array\nitems:\n" + indent(nested)
+	}
+	leaf := "type: string\n"
+	for range 10 {
+		leaf = "type: array\nitems:\n" + indent(leaf)
+	}
+	res, err := schema.NewGenerator(schema.Options{}).Generate(strings.NewReader(`openapi: 3.1.0
+info:
+  title: Depth
+  version: "1.0.0"
+paths:
+  /deep:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+` + indent(indent(indent(indent(indent(indent(indent(indent(nested)))))))) + `components:
+  schemas:
+    Nested:
+      type: object
+      properties:
+        id:
+          type: string
+          readOnly: true
+        leaf:
+` + indent(indent(indent(indent(leaf))))))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if len(res.Diagnostics) != 1 {
+		t.Fatalf("expected one depth diagnostic, got %v", res.Diagnostics)
+	}
+	d := res.Diagnostics[0]
+	if !strings.HasPrefix(d.Pointer, "#/paths/~1deep/get/responses/200/content/application~1json/schema/items") ||
+		!strings.Contains(d.Reason, "nested deeper than 30 levels") {
+		t.Fatalf("unexpected diagnostic %v", d)
+	}
+}
+
+func TestEnumExtensionDiagnostics(t *testing.T) {
+	res, err := schema.NewGenerator(schema.Options{}).Generate(strings.NewReader(`openapi: 3.0.3
+info:
+  title: Enums
+  version: "1.0.0"
+paths: {}
+components:
+  schemas:
+    Color:
+      type: string
+      enum: [r, g]
+      x-enumNames: [Red, 2bad]
+    Size:
+      type: string
+      enum: [s, m]
+      x-enum-varnames: [Small]
+`))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	want := []schema.Diagnostic{
+		{Pointer: "#/components/schemas/Color", Reason: `x-enumNames entry "2bad" is not a valid identifier, derived the member name from its value`},
+		{Pointer: "#/components/schemas/Size", Reason: "x-enum-varnames has 1 entries for 2 enum values, ignored"},
+	}
+	if len(res.Diagnostics) != len(want) {
+		t.Fatalf("expected %v, got %v", want, res.Diagnostics)
+	}
+	for i := range want {
+		if res.Diagnostics[i] != want[i] {
+			t.Errorf("expected %v, got %v", want[i], res.Diagnostics[i])
+		}
+	}
+	for _, member := range []string{"  Red = \"r\",\n  G = \"g\",\n", "  S = \"s\",\n  M = \"m\",\n"} {
+		if !strings.Contains(res.Types, member) {
+			t.Errorf("expected fallback members %q in:\n%s", member, res.Types)
+		}
+	}
+}
+
+func indent(s string) string {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = "  " + l
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,397 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+const diffOldSpec = `
+openapi: 3.1.0
+info: {title: A, version: "1"}
+paths:
+  /pets:
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {$ref: "#/components/schemas/Pet"}
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: "#/components/schemas/NewPet"}
+      responses:
+        "201": {description: created}
+  /pets/{id}:
+    delete:
+      parameters: [{name: id, in: path, required: true, schema: {type: string}}]
+      responses:
+        "204": {description: gone}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id: {type: string}
+        name: {type: string}
+        status: {type: string, enum: [available, sold]}
+        tag: {type: string}
+    NewPet:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+        kind: {type: string, enum: [cat, dog, bird]}
+`
+
+const diffNewSpec = `
+openapi: 3.1.0
+info: {title: A, version: "2"}
+paths:
+  /pets:
+    get:
+      parameters:
+        - {name: limit, in: query, required: true, schema: {type: integer}}
+        - {name: q, in: query, schema: {type: string}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {$ref: "#/components/schemas/Pet"}
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: "#/components/schemas/NewPet"}
+      responses:
+        "201": {description: created}
+        "409": {description: conflict}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id: {type: integer}
+        name: {type: string, nullable: true}
+        status: {type: string, enum: [available, sold, pending]}
+        color: {type: string}
+    NewPet:
+      type: object
+      required: [name, owner]
+      properties:
+        name: {type: string}
+        owner: {type: string}
+        kind: {type: string, enum: [cat, dog]}
+`
+
+func TestDiffFilesClassifiesChanges(t *testing.T) {
+	dir := t.TempDir()
+	oldPath, newPath := filepath.Join(dir, "old.yml"), filepath.Join(dir, "new.yml")
+	writeFile(t, oldPath, diffOldSpec)
+	writeFile(t, newPath, diffNewSpec)
+
+	changes, err := schema.DiffFiles(oldPath, newPath, schema.InputAuto)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+
+	want := []schema.Change{
+		{Location: "GET /pets", Message: `query parameter "limit" became required`, Breaking: true},
+		{Location: "GET /pets", Message: `optional query parameter "q" added`},
+		{Location: "GET /pets", Message: "response 200[].color: field added"},
+		{Location: "GET /pets", Message: "response 200[].id: type changed from string to integer", Breaking: true},
+		{Location: "GET /pets", Message: "response 200[].name: became nullable", Breaking: true},
+		{Location: "GET /pets", Message: `response 200[].status: value "pending" added`, Breaking: true},
+		{Location: "GET /pets", Message: "response 200[].tag: field removed", Breaking: true},
+		{Location: "POST /pets", Message: `request body.kind: value "bird" removed`, Breaking: true},
+		{Location: "POST /pets", Message: "request body.owner: required field added", Breaking: true},
+		{Location: "POST /pets", Message: "response 409 added"},
+		{Location: "/pets/{id}", Message: "route removed", Breaking: true},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d: %#v", len(want), len(changes), changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d:\n got: %#v\nwant: %#v", i, changes[i], want[i])
+		}
+	}
+	if n := schema.BreakingChanges(changes); n != 8 {
+		t.Fatalf("expected 8 breaking changes, got %d", n)
+	}
+
+	out, err := schema.FormatChanges(changes, schema.DiffJSON)
+	if err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	var decoded []schema.Change
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil || len(decoded) != len(want) {
+		t.Fatalf("unexpected JSON output (%v):\n%s", err, out)
+	}
+
+	md, err := schema.FormatChanges(changes, schema.DiffMarkdown)
+	if err != nil {
+		t.Fatalf("markdown: %v", err)
+	}
+	if !strings.Contains(md, "### Breaking changes (8)") || !strings.Contains(md, "### Non-breaking changes (3)") {
+		t.Fatalf("unexpected markdown output:\n%s", md)
+	}
+
+	if _, err := schema.FormatChanges(changes, "xml"); !errors.Is(err, schema.ErrUnknownDiffFormat) {
+		t.Fatalf("expected ErrUnknownDiffFormat, got %v", err)
+	}
+}
+
+func TestDiffFilesWithoutChanges(t *testing.T) {
+	path := filepath.Join("fixtures", "client.fixture.yml")
+	changes, err := schema.DiffFiles(path, path, schema.InputAuto)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected no changes, got %#v", changes)
+	}
+}
+
+const diffCompositionOld = `
+openapi: 3.1.0
+info: {title: A, version: "1"}
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: "#/components/schemas/NewPet"}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Animal"}
+  /toys:
+    post:
+      responses:
+        "204": {description: ok}
+  /food:
+    post:
+      responses:
+        "204": {description: ok}
+components:
+  schemas:
+    Base:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+    NewPet:
+      allOf:
+        - $ref: "#/components/schemas/Base"
+        - type: object
+          properties:
+            tag: {type: string}
+    Animal:
+      oneOf:
+        - $ref: "#/components/schemas/Cat"
+        - type: object
+          required: [bark]
+          properties:
+            bark: {type: boolean}
+    Cat:
+      type: object
+      required: [meow]
+      properties:
+        meow: {type: boolean}
+`
+
+const diffCompositionNew = `
+openapi: 3.1.0
+info: {title: A, version: "2"}
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: "#/components/schemas/NewPet"}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Animal"}
+  /toys:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {type: string}
+      responses:
+        "204": {description: ok}
+  /food:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {type: string}
+      responses:
+        "204": {description: ok}
+components:
+  schemas:
+    Base:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+    NewPet:
+      allOf:
+        - $ref: "#/components/schemas/Base"
+        - type: object
+          properties:
+            tag: {type: string}
+            color: {type: string}
+    Animal:
+      oneOf:
+        - $ref: "#/components/schemas/Cat"
+        - type: object
+          required: [bark]
+          properties:
+            bark: {type: boolean}
+            loud: {type: boolean}
+    Cat:
+      type: object
+      required: [meow]
+      properties:
+        meow: {type: boolean}
+        purr: {type: boolean}
+`
+
+func TestDiffComparesCompositionsStructurally(t *testing.T) {
+	dir := t.TempDir()
+	oldPath, newPath := filepath.Join(dir, "old.yml"), filepath.Join(dir, "new.yml")
+	writeFile(t, oldPath, diffCompositionOld)
+	writeFile(t, newPath, diffCompositionNew)
+
+	changes, err := schema.DiffFiles(oldPath, newPath, schema.InputAuto)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	want := []schema.Change{
+		{Location: "POST /food", Message: "required request body added", Breaking: true},
+		{Location: "POST /pets", Message: "request body.color: field added"},
+		{Location: "POST /pets", Message: "response 200.purr: field added"},
+		{Location: "POST /pets", Message: "response 200.loud: field added"},
+		{Location: "POST /toys", Message: "optional request body added"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d: %#v", len(want), len(changes), changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d:\n got: %#v\nwant: %#v", i, changes[i], want[i])
+		}
+	}
+}
+
+const diffWideningOld = `
+openapi: 3.1.0
+info: {title: A, version: "1"}
+paths:
+  /pets:
+    post:
+      parameters:
+        - {name: sort, in: query, schema: {type: string, enum: [asc, desc]}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                kind: {type: string, enum: [cat, dog]}
+                mode: {type: string}
+                count: {type: number}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  status: {type: string}
+                  size: {type: integer}
+`
+
+const diffWideningNew = `
+openapi: 3.1.0
+info: {title: A, version: "2"}
+paths:
+  /pets:
+    post:
+      parameters:
+        - {name: sort, in: query, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                kind: {type: string}
+                mode: {type: string, enum: [fast, slow]}
+                count: {type: integer}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  status: {type: string, enum: [available, sold]}
+                  size: {type: number}
+`
+
+func TestDiffClassifiesWideningByDirection(t *testing.T) {
+	dir := t.TempDir()
+	oldPath, newPath := filepath.Join(dir, "old.yml"), filepath.Join(dir, "new.yml")
+	writeFile(t, oldPath, diffWideningOld)
+	writeFile(t, newPath, diffWideningNew)
+
+	changes, err := schema.DiffFiles(oldPath, newPath, schema.InputAuto)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	want := []schema.Change{
+		{Location: "POST /pets", Message: `query parameter "sort": type widened from ("asc" | "desc") to string`},
+		{Location: "POST /pets", Message: "request body.count: type narrowed from number to integer", Breaking: true},
+		{Location: "POST /pets", Message: `request body.kind: type widened from ("cat" | "dog") to string`},
+		{Location: "POST /pets", Message: `request body.mode: type narrowed from string to ("fast" | "slow")`, Breaking: true},
+		{Location: "POST /pets", Message: "response 200.size: type widened from integer to number", Breaking: true},
+		{Location: "POST /pets", Message: `response 200.status: type narrowed from string to ("available" | "sold")`},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d: %#v", len(want), len(changes), changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d:\n got: %#v\nwant: %#v", i, changes[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+const enumStyleSpec = `openapi: 3.0.3
+info:
+  title: Enums
+  version: "1.0.0"
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        status:
+          type: string
+          nullable: true
+          enum: [available, sold]
+        level:
+          type: integer
+          enum: [1, 2]
+`
+
+func TestEnumStyles(t *testing.T) {
+	cases := map[schema.EnumStyle][]string{
+		"": {
+			"export const enum PetLevelEnum {\n  VALUE_1 = 1,\n  VALUE_2 = 2,\n}\n",
+			"export const enum PetStatusEnum {\n  AVAILABLE = \"available\",\n  SOLD = \"sold\",\n}\n",
+		},
+		schema.EnumPlain: {
+			"export enum PetLevelEnum {\n  VALUE_1 = 1,\n  VALUE_2 = 2,\n}\n",
+			"export enum PetStatusEnum {\n  AVAILABLE = \"available\",\n  SOLD = \"sold\",\n}\n",
+		},
+		schema.EnumUnion: {
+			"export type PetLevelEnum = 1 | 2;\n",
+			"export type PetStatusEnum = \"available\" | \"sold\";\n",
+		},
+		schema.EnumObject: {
+			"export const PetLevelEnum = {\n  VALUE_1: 1,\n  VALUE_2: 2,\n} as const;\n" +
+				"export type PetLevelEnum = (typeof PetLevelEnum)[keyof typeof PetLevelEnum];\n",
+			"export const PetStatusEnum = {\n  AVAILABLE: \"available\",\n  SOLD: \"sold\",\n} as const;\n" +
+				"export type PetStatusEnum = (typeof PetStatusEnum)[keyof typeof PetStatusEnum];\n",
+		},
+	}
+
+	for style, wants := range cases {
+		res, err := schema.NewGenerator(schema.Options{EnumStyle: style}).Generate(strings.NewReader(enumStyleSpec))
+		if err != nil {
+			t.Fatalf("generate %q: %v", style, err)
+		}
+		wants = append(wants, "level?: PetLevelEnum;", "status?: (PetStatusEnum | null);")
+		for _, want := range wants {
+			if !strings.Contains(res.Types, want) {
+				t.Fatalf("style %q: missing:\n%s\nin:\n%s", style, want, res.Types)
+			}
+		}
+	}
+}
+
+func TestUnknownEnumStyle(t *testing.T) {
+	_, err := schema.NewGenerator(schema.Options{EnumStyle: "bogus"}).Generate(strings.NewReader(enumStyleSpec))
+	if !errors.Is(err, schema.ErrUnknownEnumStyle) {
+		t.Fatalf("expected ErrUnknownEnumStyle, got %v", err)
+	}
+}
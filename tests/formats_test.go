@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+const formatsSpec = `openapi: 3.1.0
+info:
+  title: Formats
+  version: "1.0.0"
+paths: {}
+components:
+  schemas:
+    Upload:
+      type: object
+      required: [id, file, createdAt, ids, owner]
+      properties:
+        id:
+          type: integer
+          format: int64
+        file:
+          type: string
+          format: binary
+        createdAt:
+          type: string
+          format: date-time
+        ids:
+          type: array
+          items:
+            type: string
+            format: uuid
+        owner:
+          type: [string, "null"]
+          format: email
+`
+
+func TestFormatTypes(t *testing.T) {
+	cases := []struct {
+		formats map[string]string
+		want    []string
+	}{
+		{
+			want: []string{
+				"id: number;",
+				"file: Blob;",
+				"createdAt: string;",
+				"ids: string[];",
+				"owner: (string | null);",
+			},
+		},
+		{
+			formats: map[string]string{
+				"int64":     "bigint",
+				"binary":    "string",
+				"date-time": "Date",
+				"uuid":      "`${string}-${string}-${string}-${string}-${string}`",
+				"email":     `string & { readonly __brand: "Email" }`,
+			},
+			want: []string{
+				"id: bigint;",
+				"file: string;",
+				"createdAt: Date;",
+				"ids: (`${string}-${string}-${string}-${string}-${string}`)[];",
+				`owner: ((string & { readonly __brand: "Email" }) | null);`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := schema.NewGenerator(schema.Options{Formats: tc.formats}).Generate(strings.NewReader(formatsSpec))
+		if err != nil {
+			t.Fatalf("generate: %v", err)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(res.Types, want) {
+				t.Errorf("formats %v: missing %q in:\n%s", tc.formats, want, res.Types)
+			}
+		}
+	}
+}
+
+func TestZodFormatTypes(t *testing.T) {
+	res, err := schema.NewGenerator(schema.Options{Zod: true, Formats: map[string]string{
+		"int64":     "bigint",
+		"date-time": "Date",
+		"uuid":      "`${string}-${string}`",
+	}}).Generate(strings.NewReader(formatsSpec))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	for _, want := range []string{
+		"  createdAt: z.coerce.date(),\n",
+		"  file: z.instanceof(Blob),\n",
+		"  id: z.coerce.bigint(),\n",
+		"  ids: z.array(z.custom<(`${string}-${string}`)>()),\n",
+		"  owner: z.string().email().nullable(),\n",
+	} {
+		if !strings.Contains(res.Zod, want) {
+			t.Errorf("missing %q in:\n%s", want, res.Zod)
+		}
+	}
+}
@@ -19,16 +19,7 @@ func TestGenerateSnapshotsMatchFixtures(t *testing.T) {
 		t.Fatalf("create tmp dir: %v", err)
 	}
 
-	oldNow := schema.Now
-	oldVersion := schema.CLIVersion
-	schema.Now = func() time.Time {
-		return time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
-	}
-	schema.CLIVersion = "dev"
-	defer func() {
-		schema.Now = oldNow
-		schema.CLIVersion = oldVersion
-	}()
+	pinGeneratorInfo(t)
 
 	entries, err := os.ReadDir(fixturesDir)
 	if err != nil {
@@ -72,3 +63,67 @@ func TestGenerateSnapshotsMatchFixtures(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateClientSnapshot(t *testing.T) {
+	tmpDir := ".generated"
+	if err := os.MkdirAll(tmpDir, 0o755); err != nil {
+		t.Fatalf("create tmp dir: %v", err)
+	}
+
+	pinGeneratorInfo(t)
+
+	snapshotName := "client.fetch-client.snapshot.ts"
+	outPath := filepath.Join(tmpDir, snapshotName)
+	typesPath := filepath.Join(tmpDir, "client.yml.snapshot.ts")
+	if err := schema.WriteClient(filepath.Join("fixtures", "client.fixture.yml"), outPath, typesPath, schema.InputYAML); err != nil {
+		t.Fatalf("generate client: %v", err)
+	}
+
+	assertSnapshot(t, snapshotName, outPath)
+}
+
+func TestGenerateZodSnapshot(t *testing.T) {
+	tmpDir := ".generated"
+	if err := os.MkdirAll(tmpDir, 0o755); err != nil {
+		t.Fatalf("create tmp dir: %v", err)
+	}
+
+	pinGeneratorInfo(t)
+
+	snapshotName := "zod.zod.snapshot.ts"
+	outPath := filepath.Join(tmpDir, snapshotName)
+	if err := schema.WriteZod(filepath.Join("fixtures", "zod.fixture.yml"), outPath, schema.InputYAML); err != nil {
+		t.Fatalf("generate zod: %v", err)
+	}
+
+	assertSnapshot(t, snapshotName, outPath)
+}
+
+func assertSnapshot(t *testing.T, snapshotName, outPath string) {
+	t.Helper()
+	expected, err := os.ReadFile(filepath.Join("snapshots", snapshotName))
+	if err != nil {
+		t.Fatalf("read %s: %v", snapshotName, err)
+	}
+	got, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read %s: %v", outPath, err)
+	}
+	if normalizeSnapshot(string(expected)) != normalizeSnapshot(string(got)) {
+		t.Fatalf("snapshot mismatch: %s vs %s\n%s", snapshotName, outPath, diffText(normalizeSnapshot(string(expected)), normalizeSnapshot(string(got))))
+	}
+}
+
+func pinGeneratorInfo(t *testing.T) {
+	t.Helper()
+	oldNow := schema.Now
+	oldVersion := schema.CLIVersion
+	schema.Now = func() time.Time {
+		return time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	}
+	schema.CLIVersion = "dev"
+	t.Cleanup(func() {
+		schema.Now = oldNow
+		schema.CLIVersion = oldVersion
+	})
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+const generatorSpec = `openapi: 3.1.0
+info:
+  title: Library
+  version: "1.0.0"
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "./schemas/pet.yml#/Pet"
+`
+
+const generatorPet = `Pet:
+  type: object
+  required: [name]
+  properties:
+    name:
+      type: string
+      minLength: 1
+`
+
+func fixedGenerator(opts schema.Options) *schema.Generator {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	opts.Now = func() time.Time { return at }
+	return schema.NewGenerator(opts)
+}
+
+func TestGeneratorGenerateFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"specs/openapi.yml":     {Data: []byte(generatorSpec)},
+		"specs/schemas/pet.yml": {Data: []byte(generatorPet)},
+	}
+
+	g := fixedGenerator(schema.Options{Version: "test", Client: true, Zod: true, TypesImport: "./api"})
+	res, err := g.GenerateFS(fsys, "specs/openapi.yml")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if res.OpenAPI != "3.1.0" {
+		t.Fatalf("unexpected OpenAPI version %q", res.OpenAPI)
+	}
+	for out, want := range map[string]string{
+		res.Types:  "Generated at: 2026-01-02T03:04:05Z",
+		res.Client: `import type { Routes } from "./api";`,
+		res.Zod:    "z.string().min(1)",
+	} {
+		if !strings.Contains(out, "Generator: openapi-tsgen@test") || !strings.Contains(out, want) {
+			t.Fatalf("expected output to contain %q:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(res.Types, "Pet: {") {
+		t.Fatalf("expected referenced Pet schema in types:\n%s", res.Types)
+	}
+}
+
+func TestGeneratorGenerateReaderSkipsOptionalOutputs(t *testing.T) {
+	g := fixedGenerator(schema.Options{})
+	res, err := g.Generate(strings.NewReader(`{"openapi": "3.0.3", "info": {"title": "R", "version": "1"}, "paths": {}}`))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if res.Types == "" || res.Client != "" || res.Zod != "" {
+		t.Fatalf("unexpected result: %#v", res)
+	}
+}
+
+func TestGeneratorIsSafeForConcurrentUse(t *testing.T) {
+	fsys := fstest.MapFS{
+		"openapi.yml": {Data: []byte(strings.ReplaceAll(generatorSpec, "./schemas/pet.yml", "./pet.yml"))},
+		"pet.yml":     {Data: []byte(generatorPet)},
+	}
+	g := fixedGenerator(schema.Options{Client: true, Zod: true})
+
+	want, err := g.GenerateFS(fsys, "openapi.yml")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := g.GenerateFS(fsys, "openapi.yml")
+			if err != nil {
+				errs <- err
+				return
+			}
+			if res.Types != want.Types || res.Client != want.Client || res.Zod != want.Zod {
+				t.Error("concurrent generation produced different output")
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("generate: %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+func TestToIRBuildsTypeTree(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "openapi.yml")
+	writeFile(t, path, `openapi: 3.1.0
+info:
+  title: Tree
+  version: "1.0.0"
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        tags:
+          type: array
+          items:
+            type: string
+        owner:
+          type: string
+          nullable: true
+`)
+
+	doc, err := schema.LoadDocument(path, schema.InputYAML)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	ir, err := schema.ToIR(doc)
+	if err != nil {
+		t.Fatalf("ir: %v", err)
+	}
+
+	pet := ir.ComponentsSchemas["Pet"]
+	if pet == nil || pet.Kind != schema.TypeObject || len(pet.Fields) != 3 {
+		t.Fatalf("expected Pet object with 3 fields, got %#v", pet)
+	}
+	fields := map[string]schema.Field{}
+	for _, f := range pet.Fields {
+		fields[f.Name] = f
+	}
+	if f := fields["name"]; f.Optional || f.Type.Kind != schema.TypePrimitive || f.Type.Name != "string" {
+		t.Fatalf("unexpected name field: %#v", f)
+	}
+	if f := fields["tags"]; !f.Optional || f.Type.Kind != schema.TypeArray || f.Type.Elem.Name != "string" {
+		t.Fatalf("unexpected tags field: %#v", f)
+	}
+	if f := fields["owner"]; f.Type.Kind != schema.TypeUnion || len(f.Type.Items) != 2 {
+		t.Fatalf("unexpected owner field: %#v", f)
+	}
+
+	op := ir.Paths["/pets/{id}"].Ops["get"]
+	if op.RequestBody != nil {
+		t.Fatalf("expected no request body, got %#v", op.RequestBody)
+	}
+	if p := op.PathParams["id"]; !p.Required || schema.RenderTS(p.Type) != "string" {
+		t.Fatalf("unexpected id param: %#v", p)
+	}
+	if resp := op.Responses["200"]; resp.Kind != schema.TypeObject || len(resp.Fields) != 3 {
+		t.Fatalf("expected inlined Pet response, got %#v", resp)
+	}
+}
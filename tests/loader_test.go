@@ -0,0 +1,170 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+func TestLoadDocumentDetectsRefCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "openapi.yml"), `openapi: 3.1.0
+info:
+  title: Cycle
+  version: "1.0.0"
+paths:
+  /a:
+    $ref: "./a.yml"
+`)
+	writeFile(t, filepath.Join(dir, "a.yml"), `$ref: "./b.yml"
+`)
+	writeFile(t, filepath.Join(dir, "b.yml"), `$ref: "./a.yml"
+`)
+
+	_, err := schema.LoadDocument(filepath.Join(dir, "openapi.yml"), schema.InputYAML)
+	if !errors.Is(err, schema.ErrRefCycle) {
+		t.Fatalf("expected ErrRefCycle, got %v", err)
+	}
+}
+
+func TestLoadDocumentMissingRefTarget(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "openapi.yml"), `openapi: 3.1.0
+info:
+  title: Missing
+  version: "1.0.0"
+components:
+  schemas:
+    Pet:
+      $ref: "./pet.yml#/Missing"
+`)
+	writeFile(t, filepath.Join(dir, "pet.yml"), `Pet:
+  type: object
+`)
+
+	_, err := schema.LoadDocument(filepath.Join(dir, "openapi.yml"), schema.InputYAML)
+	if !errors.Is(err, schema.ErrRefTargetNotFound) {
+		t.Fatalf("expected ErrRefTargetNotFound, got %v", err)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestLoadDocumentRejectsUnknownSwaggerVersion(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "swagger.yml")
+	writeFile(t, path, `swagger: "1.2"
+info:
+  title: Old
+  version: "1.0.0"
+paths: {}
+`)
+
+	_, err := schema.LoadDocument(path, schema.InputYAML)
+	if !errors.Is(err, schema.ErrUnsupportedSwaggerVersion) {
+		t.Fatalf("expected ErrUnsupportedSwaggerVersion, got %v", err)
+	}
+}
+
+func TestSwaggerConversionKeepsPayloadsVerbatim(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "swagger.yml")
+	writeFile(t, path, `swagger: "2.0"
+info:
+  title: Payloads
+  version: "1.0.0"
+x-meta:
+  $ref: "#/definitions/Pet"
+paths: {}
+definitions:
+  Pet:
+    type: object
+    discriminator: kind
+    x-nullable: true
+    example:
+      $ref: "#/definitions/Pet"
+      discriminator: kind
+    default:
+      type: file
+      x-nullable: true
+    x-links:
+      $ref: "#/responses/NotFound"
+    properties:
+      kind:
+        type: string
+      owner:
+        $ref: "#/definitions/Owner"
+  Owner:
+    type: string
+`)
+
+	doc, err := schema.LoadDocument(path, schema.InputYAML)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got := fmt.Sprint(doc.Extensions["x-meta"]); got != "map[$ref:#/definitions/Pet]" {
+		t.Fatalf("x-meta rewritten: %s", got)
+	}
+	pet := doc.Components.Schemas["Pet"]
+	if pet.Discriminator == nil || pet.Discriminator.PropertyName != "kind" || pet.Other["nullable"] != true {
+		t.Fatalf("schema keywords not converted: %#v", pet)
+	}
+	if got := fmt.Sprint(pet.Other["properties"]); !strings.Contains(got, "owner:map[$ref:#/components/schemas/Owner]") {
+		t.Fatalf("property ref not rewritten: %s", got)
+	}
+	if got := fmt.Sprint(pet.Example); got != "map[$ref:#/definitions/Pet discriminator:kind]" {
+		t.Fatalf("example rewritten: %s", got)
+	}
+	for key, want := range map[string]string{
+		"default": "map[type:file x-nullable:true]",
+		"x-links": "map[$ref:#/responses/NotFound]",
+	} {
+		if got := fmt.Sprint(pet.Other[key]); got != want {
+			t.Fatalf("%s = %s, want %s", key, got, want)
+		}
+	}
+}
+
+func TestSchemaFilesListsReferencedFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "openapi.yml"), `openapi: 3.1.0
+info:
+  title: Files
+  version: "1.0.0"
+components:
+  schemas:
+    Pet:
+      $ref: "./pet.yml#/Pet"
+`)
+	writeFile(t, filepath.Join(dir, "pet.yml"), `Pet:
+  type: object
+  properties:
+    tag:
+      $ref: "./tag.yml"
+`)
+	writeFile(t, filepath.Join(dir, "tag.yml"), `type: string
+`)
+
+	files, err := schema.SchemaFiles(filepath.Join(dir, "openapi.yml"), schema.InputYAML)
+	if err != nil {
+		t.Fatalf("files: %v", err)
+	}
+	want := []string{
+		filepath.Join(dir, "openapi.yml"),
+		filepath.Join(dir, "pet.yml"),
+		filepath.Join(dir, "tag.yml"),
+	}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, files)
+	}
+}
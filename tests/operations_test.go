@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+const operationsSpec = `openapi: 3.1.0
+info:
+  title: Operations
+  version: "1.0.0"
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: string
+        "204":
+          description: empty
+    post:
+      operationId: listPets
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: string
+      responses:
+        default:
+          description: error
+  /pets/{petId}:
+    get:
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+    put:
+      operationId: get_pets_by_pet_id
+      responses:
+        "201":
+          description: created
+  /toys:
+    get:
+      operationId: listToys
+      responses:
+        "2XX":
+          description: ok
+        "4XX":
+          description: client error
+`
+
+func TestOperationsKeyedByOperationID(t *testing.T) {
+	res, err := schema.NewGenerator(schema.Options{OperationAliases: true}).Generate(strings.NewReader(operationsSpec))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	for _, want := range []string{
+		"export type Operations = {\n" +
+			"  getPetsByPetId: Routes[\"/pets/{petId}\"][\"get\"];\n" +
+			"  get_pets_by_pet_id: Routes[\"/pets/{petId}\"][\"put\"];\n" +
+			"  listPets: Routes[\"/pets\"][\"get\"];\n" +
+			"  listPets2: Routes[\"/pets\"][\"post\"];\n" +
+			"  listToys: Routes[\"/toys\"][\"get\"];\n" +
+			"};\n",
+		"export type ListPetsQuery = Routes[\"/pets\"][\"get\"][\"query\"];\n" +
+			"export type ListPetsResponse = Routes[\"/pets\"][\"get\"][\"responses\"][200] | Routes[\"/pets\"][\"get\"][\"responses\"][204];\n",
+		"export type ListPets2Body = Routes[\"/pets\"][\"post\"][\"requestBody\"];\n" +
+			"export type ListPets2Response = Routes[\"/pets\"][\"post\"][\"responses\"][\"default\"];\n",
+		"export type GetPetsByPetIdResponse = Routes[\"/pets/{petId}\"][\"put\"][\"responses\"][201];\n",
+		"export type GetPetsByPetId2Params = Routes[\"/pets/{petId}\"][\"get\"][\"params\"];\n",
+		"export type ListToysResponse = Routes[\"/toys\"][\"get\"][\"responses\"][\"2XX\"];\n",
+	} {
+		if !strings.Contains(res.Types, want) {
+			t.Fatalf("missing:\n%s\nin:\n%s", want, res.Types)
+		}
+	}
+	if strings.Contains(res.Types, "ListPetsBody") {
+		t.Fatalf("unexpected alias for missing request body:\n%s", res.Types)
+	}
+}
+
+func TestOperationAliasesAreOptIn(t *testing.T) {
+	res, err := schema.NewGenerator(schema.Options{}).Generate(strings.NewReader(operationsSpec))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if !strings.Contains(res.Types, "export type Operations = {") {
+		t.Fatalf("missing Operations type:\n%s", res.Types)
+	}
+	if strings.Contains(res.Types, "ListPetsQuery") {
+		t.Fatalf("aliases emitted without OperationAliases:\n%s", res.Types)
+	}
+}
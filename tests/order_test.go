@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+	"go.yaml.in/yaml/v3"
+)
+
+const preserveOrderSpec = `openapi: 3.1.0
+info:
+  title: Order
+  version: "1.0.0"
+paths:
+  /users/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    put:
+      parameters:
+        - $ref: "#/components/parameters/Verbose"
+        - name: dryRun
+          in: query
+          schema:
+            type: boolean
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/User"
+      responses:
+        "404":
+          description: missing
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/User"
+    get:
+      responses:
+        "200":
+          description: ok
+  /accounts:
+    get:
+      responses:
+        "200":
+          description: ok
+components:
+  parameters:
+    Verbose:
+      name: verbose
+      in: query
+      schema:
+        type: boolean
+  schemas:
+    User:
+      type: object
+      properties:
+        id:
+          type: string
+        name:
+          type: string
+        address:
+          type: object
+          properties:
+            zip:
+              type: string
+            city:
+              type: string
+    Account:
+      type: object
+      properties:
+        owner:
+          $ref: "#/components/schemas/User"
+        balance:
+          type: number
+    Settings:
+      type: object
+      additionalProperties:
+        type: object
+        properties:
+          zz:
+            type: string
+          aa:
+            type: string
+`
+
+func TestPreserveOrder(t *testing.T) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(preserveOrderSpec), &node); err != nil {
+		t.Fatalf("parse spec: %v", err)
+	}
+	jsonSpec := nodeJSON(t, node.Content[0])
+
+	want := []string{
+		"  schemas: {\n    User: {\n      id?: string;\n      name?: string;\n      address?: {\n        zip?: string;\n        city?: string;\n      };\n    };\n    Account: {\n      owner?:",
+		"export type Routes = {\n  \"/users/{id}\": {\n    put: {",
+		"      query: {\n        verbose?: Components[\"parameters\"][\"Verbose\"];\n        dryRun?: boolean;\n      };",
+		"      responses: {\n        404: never;\n        200: {\n          id?: string;",
+		"    Settings: Record<string, {\n      zz?: string;\n      aa?: string;\n    }>;",
+		"    get: {\n      params: {\n        id: string;\n      };\n      responses: {\n        200: never;\n      };\n    };\n  };\n  \"/accounts\": {",
+	}
+	var outputs []string
+	for _, input := range []string{preserveOrderSpec, jsonSpec} {
+		res, err := schema.NewGenerator(schema.Options{PreserveOrder: true, Zod: true}).Generate(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("generate: %v", err)
+		}
+		for _, w := range want {
+			if !strings.Contains(res.Types, w) {
+				t.Fatalf("missing %q in:\n%s", w, res.Types)
+			}
+		}
+		if w := "export const UserSchema = z.object({\n  id: z.string().optional(),\n  name: z.string().optional(),\n  address: z.object({\n    zip: z.string().optional(),"; !strings.Contains(res.Zod, w) {
+			t.Fatalf("missing %q in:\n%s", w, res.Zod)
+		}
+		outputs = append(outputs, res.Types)
+	}
+	if outputs[0] != outputs[1] {
+		t.Fatalf("YAML and JSON outputs differ:\n%s", diffText(outputs[0], outputs[1]))
+	}
+
+	res, err := schema.NewGenerator(schema.Options{}).Generate(strings.NewReader(preserveOrderSpec))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if !strings.Contains(res.Types, "export type Routes = {\n  \"/accounts\": {") {
+		t.Fatalf("expected sorted routes without PreserveOrder:\n%s", res.Types)
+	}
+}
+
+func nodeJSON(t *testing.T, n *yaml.Node) string {
+	t.Helper()
+	switch n.Kind {
+	case yaml.MappingNode:
+		parts := make([]string, 0, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			parts = append(parts, nodeJSON(t, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: n.Content[i].Value})+":"+nodeJSON(t, n.Content[i+1]))
+		}
+		return "{" + strings.Join(parts, ",") + "}"
+	case yaml.SequenceNode:
+		parts := make([]string, 0, len(n.Content))
+		for _, c := range n.Content {
+			parts = append(parts, nodeJSON(t, c))
+		}
+		return "[" + strings.Join(parts, ",") + "]"
+	default:
+		var v any
+		if err := n.Decode(&v); err != nil {
+			t.Fatalf("decode %q: %v", n.Value, err)
+		}
+		out, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("encode %q: %v", n.Value, err)
+		}
+		return string(out)
+	}
+}
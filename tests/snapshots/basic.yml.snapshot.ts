@@ -23,8 +23,13 @@ export type Components = {
 
 export type Routes = {
   "/ping": {
+    /** @summary Ping */
     get: {
       query: {
+        /**
+         * @minimum 1
+         * @maximum 100
+         */
         limit?: number;
       };
       headers: {
@@ -36,3 +41,7 @@ export type Routes = {
     };
   };
 };
+
+export type Operations = {
+  ping: Routes["/ping"]["get"];
+};
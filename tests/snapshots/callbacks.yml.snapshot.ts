@@ -0,0 +1,70 @@
+/*
+ * @Warning: THIS FILE IS AUTO-GENERATED - DO NOT EDIT
+ *
+ * Generator: openapi-tsgen@dev
+ * OpenAPI version: 3.1.0
+ * Generated at: 2026-02-10T00:00:00Z
+ */
+
+export const enum PaymentEventStatusEnum {
+  PAID = "paid",
+  FAILED = "failed",
+}
+
+export type Components = {
+  schemas: {
+    PaymentEvent: {
+      status: PaymentEventStatusEnum;
+    };
+  };
+};
+
+export type Routes = {
+  "/payments": {
+    post: {
+      requestBody: {
+        /** @format uri */
+        callbackUrl: string;
+      };
+      responses: {
+        201: never;
+      };
+      callbacks: {
+        paymentStatus: {
+          "{$request.body#/callbackUrl}": {
+            post: {
+              headers: {
+                "X-Signature": string;
+              };
+              requestBody: {
+                status: PaymentEventStatusEnum;
+              };
+              responses: {
+                204: never;
+              };
+            };
+          };
+        };
+        refund: {
+          "{$request.body#/callbackUrl}/refunds": {
+            /** @summary Refund issued */
+            post: {
+              requestBody: {
+                amount?: number;
+              };
+              responses: {
+                200: {
+                  received?: boolean;
+                };
+              };
+            };
+          };
+        };
+      };
+    };
+  };
+};
+
+export type Operations = {
+  createPayment: Routes["/payments"]["post"];
+};
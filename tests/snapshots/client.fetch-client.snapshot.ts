@@ -0,0 +1,234 @@
+/*
+ * @Warning: THIS FILE IS AUTO-GENERATED - DO NOT EDIT
+ *
+ * Generator: openapi-tsgen@dev
+ * OpenAPI version: 3.1.0
+ * Generated at: 2026-02-10T00:00:00Z
+ */
+
+import type { Routes } from "./client.yml.snapshot";
+
+type HttpMethod = "delete" | "get" | "head" | "options" | "patch" | "post" | "put" | "trace";
+
+type ParamStyle = "simple" | "label" | "matrix" | "form" | "spaceDelimited" | "pipeDelimited" | "deepObject";
+
+type ParamSerialization = {
+  style: ParamStyle;
+  explode: boolean;
+};
+
+type OperationSerialization = {
+  path?: Record<string, ParamSerialization>;
+  query?: Record<string, ParamSerialization>;
+};
+
+type RoutePath<M extends HttpMethod> = {
+  [P in keyof Routes]: M extends keyof Routes[P] ? P : never;
+}[keyof Routes];
+
+type RouteOperation<P, M extends HttpMethod> = P extends keyof Routes
+  ? M extends keyof Routes[P]
+    ? Routes[P][M]
+    : never
+  : never;
+
+type OptionalIfEmpty<K extends string, T> = {} extends T ? { [Q in K]?: T } : { [Q in K]: T };
+
+export type RequestOptions<O> = (O extends { params: infer T } ? { params: T } : { params?: never }) &
+  (O extends { query: infer T } ? OptionalIfEmpty<"query", T> : { query?: never }) &
+  (O extends { headers: infer T }
+    ? OptionalIfEmpty<"headers", T & Record<string, unknown>>
+    : { headers?: Record<string, unknown> }) &
+  (O extends { requestBody: infer T } ? { body: T } : { body?: never }) & {
+    init?: Omit<RequestInit, "method" | "body" | "headers">;
+  };
+
+type RequestArgs<O> = {} extends RequestOptions<O> ? [options?: RequestOptions<O>] : [options: RequestOptions<O>];
+
+type ResponseData<R> = R extends { headers: unknown; body: infer B } ? B : R;
+
+type ResponseStatus<S> = S extends "default" ? number : S;
+
+export type ApiResponse<O> = O extends { responses: infer R }
+  ? {
+      [S in keyof R]: {
+        status: ResponseStatus<S>;
+        data: ResponseData<R[S]>;
+        response: Response;
+      };
+    }[keyof R]
+  : never;
+
+export type ClientOptions = {
+  baseUrl?: string;
+  fetch?: typeof fetch;
+  headers?: Record<string, string>;
+};
+
+type RawRequestOptions = {
+  params?: Record<string, unknown>;
+  query?: Record<string, unknown>;
+  headers?: Record<string, unknown>;
+  body?: unknown;
+  init?: RequestInit;
+};
+
+const paramSerialization: Record<string, Partial<Record<HttpMethod, OperationSerialization>>> = {
+  "/pets": {
+    get: {
+      query: {
+        filter: { style: "deepObject", explode: true },
+        ids: { style: "pipeDelimited", explode: false },
+        tags: { style: "form", explode: false },
+      },
+    },
+  },
+  "/pets/{petId}/photos/{size}": {
+    get: {
+      path: {
+        petId: { style: "label", explode: false },
+        size: { style: "matrix", explode: true },
+      },
+    },
+  },
+};
+
+function buildPath(path: string, params: Record<string, unknown>, styles: Record<string, ParamSerialization>): string {
+  return path.replace(/\{([^}]+)\}/g, (_match: string, name: string) => {
+    const serialization = styles[name] ?? { style: "simple", explode: false };
+    return serializePathParam(name, params[name], serialization);
+  });
+}
+
+function serializePathParam(name: string, value: unknown, { style, explode }: ParamSerialization): string {
+  const prefix = style === "label" ? "." : style === "matrix" ? ";" : "";
+  if (Array.isArray(value)) {
+    const items = value.map((item) => encodeURIComponent(String(item)));
+    if (style === "matrix") {
+      return explode ? items.map((item) => ";" + name + "=" + item).join("") : ";" + name + "=" + items.join(",");
+    }
+    return prefix + items.join(explode && style === "label" ? "." : ",");
+  }
+  if (value !== null && typeof value === "object") {
+    const entries = Object.entries(value).map(([k, v]) => [encodeURIComponent(k), encodeURIComponent(String(v))]);
+    if (explode) {
+      const separator = style === "label" ? "." : style === "matrix" ? ";" : ",";
+      return prefix + entries.map(([k, v]) => k + "=" + v).join(separator);
+    }
+    const flat = entries.flat().join(",");
+    return style === "matrix" ? ";" + name + "=" + flat : prefix + flat;
+  }
+  const encoded = encodeURIComponent(String(value));
+  return style === "matrix" ? ";" + name + "=" + encoded : prefix + encoded;
+}
+
+function buildQuery(query: Record<string, unknown>, styles: Record<string, ParamSerialization>): string {
+  const parts: string[] = [];
+  for (const [name, value] of Object.entries(query)) {
+    if (value === undefined || value === null) {
+      continue;
+    }
+    const { style, explode } = styles[name] ?? { style: "form", explode: true };
+    const key = encodeURIComponent(name);
+    if (Array.isArray(value)) {
+      const items = value.map((item) => encodeURIComponent(String(item)));
+      if (explode) {
+        for (const item of items) {
+          parts.push(key + "=" + item);
+        }
+        continue;
+      }
+      const separator = style === "spaceDelimited" ? "%20" : style === "pipeDelimited" ? "|" : ",";
+      parts.push(key + "=" + items.join(separator));
+      continue;
+    }
+    if (typeof value === "object") {
+      const entries = Object.entries(value).map(([k, v]) => [encodeURIComponent(k), encodeURIComponent(String(v))]);
+      if (style === "deepObject") {
+        for (const [k, v] of entries) {
+          parts.push(key + "[" + k + "]=" + v);
+        }
+      } else if (explode) {
+        for (const [k, v] of entries) {
+          parts.push(k + "=" + v);
+        }
+      } else {
+        parts.push(key + "=" + entries.flat().join(","));
+      }
+      continue;
+    }
+    parts.push(key + "=" + encodeURIComponent(String(value)));
+  }
+  return parts.join("&");
+}
+
+function isRawBody(body: unknown): body is BodyInit {
+  return (
+    typeof body === "string" ||
+    (typeof Blob !== "undefined" && body instanceof Blob) ||
+    (typeof FormData !== "undefined" && body instanceof FormData) ||
+    (typeof URLSearchParams !== "undefined" && body instanceof URLSearchParams) ||
+    body instanceof ArrayBuffer ||
+    ArrayBuffer.isView(body)
+  );
+}
+
+async function readBody(response: Response): Promise<unknown> {
+  if (response.status === 204 || response.headers.get("content-length") === "0") {
+    return undefined;
+  }
+  const contentType = response.headers.get("content-type") ?? "";
+  if (contentType.includes("json")) {
+    return response.json();
+  }
+  if (contentType === "" || contentType.startsWith("text/")) {
+    return response.text();
+  }
+  return response.blob();
+}
+
+export function createClient(options: ClientOptions = {}) {
+  const baseUrl = (options.baseUrl ?? "").replace(/\/+$/, "");
+  const fetchImpl = options.fetch ?? globalThis.fetch;
+
+  async function request<R>(method: HttpMethod, path: string, args?: unknown): Promise<R> {
+    const opts = (args ?? {}) as RawRequestOptions;
+    const serialization = paramSerialization[path]?.[method] ?? {};
+    let url = baseUrl + buildPath(path, opts.params ?? {}, serialization.path ?? {});
+    const query = buildQuery(opts.query ?? {}, serialization.query ?? {});
+    if (query !== "") {
+      url += "?" + query;
+    }
+
+    const headers = new Headers(options.headers);
+    for (const [name, value] of Object.entries(opts.headers ?? {})) {
+      if (value !== undefined && value !== null) {
+        headers.set(name, String(value));
+      }
+    }
+
+    let body: BodyInit | undefined;
+    if (opts.body !== undefined) {
+      if (isRawBody(opts.body)) {
+        body = opts.body;
+      } else {
+        body = JSON.stringify(opts.body);
+        if (!headers.has("content-type")) {
+          headers.set("content-type", "application/json");
+        }
+      }
+    }
+
+    const response = await fetchImpl(url, { ...opts.init, method: method.toUpperCase(), headers, body });
+    return { status: response.status, data: await readBody(response), response } as R;
+  }
+
+  return {
+    get: <P extends RoutePath<"get">>(path: P, ...args: RequestArgs<RouteOperation<P, "get">>) =>
+      request<ApiResponse<RouteOperation<P, "get">>>("get", path, args[0]),
+    post: <P extends RoutePath<"post">>(path: P, ...args: RequestArgs<RouteOperation<P, "post">>) =>
+      request<ApiResponse<RouteOperation<P, "post">>>("post", path, args[0]),
+  };
+}
+
+export type Client = ReturnType<typeof createClient>;
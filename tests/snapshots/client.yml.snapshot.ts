@@ -0,0 +1,80 @@
+/*
+ * @Warning: THIS FILE IS AUTO-GENERATED - DO NOT EDIT
+ *
+ * Generator: openapi-tsgen@dev
+ * OpenAPI version: 3.1.0
+ * Generated at: 2026-02-10T00:00:00Z
+ */
+
+export type Components = {
+  schemas: {
+    Error: {
+      message: string;
+    };
+    Pet: {
+      name: string;
+    };
+  };
+};
+
+export type Routes = {
+  "/pets": {
+    /** @summary List pets */
+    get: {
+      query: {
+        filter?: {
+          kind?: string;
+        };
+        ids?: number[];
+        limit?: number;
+        tags?: string[];
+      };
+      responses: {
+        200: {
+          name: string;
+        }[];
+      };
+    };
+    /** @summary Create pet */
+    post: {
+      headers: {
+        "X-Request-Id": string;
+      };
+      requestBody: {
+        name: string;
+      };
+      responses: {
+        201: {
+          headers: {
+            Location: string;
+          };
+          body: {
+            name: string;
+          };
+        };
+        default: {
+          message: string;
+        };
+      };
+    };
+  };
+  "/pets/{petId}/photos/{size}": {
+    /** @summary Get photo */
+    get: {
+      params: {
+        petId: number;
+        size: number[];
+      };
+      responses: {
+        200: Blob;
+        404: never;
+      };
+    };
+  };
+};
+
+export type Operations = {
+  createPet: Routes["/pets"]["post"];
+  getPhoto: Routes["/pets/{petId}/photos/{size}"]["get"];
+  listPets: Routes["/pets"]["get"];
+};
@@ -14,15 +14,18 @@ export type Components = {
     };
   };
   responses: {
+    /** @description Not found */
     NotFound: string;
+    /** @description OK */
     UserList: {
       headers: {
+        /** @description Requests per minute */
         "X-Rate-Limit"?: Components["headers"]["RateLimit"];
       };
       body: {
-      email: string;
-      id: string;
-    }[];
+        email: string;
+        id: string;
+      }[];
     };
   };
   requestBodies: {
@@ -32,18 +35,28 @@ export type Components = {
     };
   };
   parameters: {
+    /**
+     * @minimum 1
+     * @maximum 100
+     */
     Limit: number;
     TraceId: string;
   };
   headers: {
+    /** @description Requests per minute */
     RateLimit: number;
   };
 };
 
 export type Routes = {
   "/users": {
+    /** @summary List users */
     get: {
       query: {
+        /**
+         * @minimum 1
+         * @maximum 100
+         */
         limit?: Components["parameters"]["Limit"];
       };
       headers: {
@@ -55,6 +68,7 @@ export type Routes = {
     };
   };
   "/users/{id}": {
+    /** @summary Update user */
     post: {
       params: {
         id: string;
@@ -67,3 +81,8 @@ export type Routes = {
     };
   };
 };
+
+export type Operations = {
+  listUsers: Routes["/users"]["get"];
+  updateUser: Routes["/users/{id}"]["post"];
+};
@@ -14,7 +14,9 @@ export const enum IfThenElseSampleKindEnum {
 export type Components = {
   schemas: {
     Account: {
+      /** @format email */
       email: string;
+      /** @format uuid */
       id?: string;
       password: string;
     };
@@ -51,17 +53,25 @@ export type Components = {
 
 export type Routes = {
   "/accounts": {
+    /** @summary Create account */
     post: {
       requestBody: {
+        /** @format email */
         email: string;
         password: string;
       };
       responses: {
         201: {
+          /** @format email */
           email: string;
+          /** @format uuid */
           id?: string;
         };
       };
     };
   };
 };
+
+export type Operations = {
+  createAccount: Routes["/accounts"]["post"];
+};
@@ -0,0 +1,119 @@
+/*
+ * @Warning: THIS FILE IS AUTO-GENERATED - DO NOT EDIT
+ *
+ * Generator: openapi-tsgen@dev
+ * OpenAPI version: 3.1.0
+ * Generated at: 2026-02-10T00:00:00Z
+ */
+
+export type Components = {
+  schemas: {
+    /**
+     * @description A pet in the store. Comments like *\/ are escaped.
+     * @see https://example.com/docs/pet
+     */
+    Pet: {
+      /**
+       * @format int64
+       * @minimum 1
+       */
+      id: number;
+      /**
+       * @description Display name
+       * @example Rex
+       * @minLength 1
+       * @maxLength 64
+       * @pattern ^[A-Za-z ]+$
+       */
+      name: string;
+      /**
+       * @deprecated
+       * @default ""
+       */
+      nickname?: string;
+      /**
+       * @example ["good","small"]
+       * @maxItems 10
+       */
+      tags?: string[];
+    };
+  };
+};
+
+export type Routes = {
+  "/pets/{petId}": {
+    /**
+     * @summary Delete pet
+     * @deprecated
+     */
+    delete: {
+      responses: {
+        204: never;
+      };
+    };
+    /**
+     * @summary Get pet
+     * @description Returns a single pet.
+     * Archived pets are included.
+     * @see https://example.com/docs/pets Pet guide
+     */
+    get: {
+      params: {
+        /**
+         * @description Pet identifier
+         * @example 42
+         * @minimum 1
+         */
+        petId: number;
+      };
+      query: {
+        /**
+         * @description Use `include` instead
+         * @deprecated
+         */
+        fields?: string;
+      };
+      responses: {
+        200: {
+          headers: {
+            /**
+             * @description Correlation id
+             * @format uuid
+             */
+            "X-Request-Id": string;
+          };
+          body: {
+            /**
+             * @format int64
+             * @minimum 1
+             */
+            id: number;
+            /**
+             * @description Display name
+             * @example Rex
+             * @minLength 1
+             * @maxLength 64
+             * @pattern ^[A-Za-z ]+$
+             */
+            name: string;
+            /**
+             * @deprecated
+             * @default ""
+             */
+            nickname?: string;
+            /**
+             * @example ["good","small"]
+             * @maxItems 10
+             */
+            tags?: string[];
+          };
+        };
+      };
+    };
+  };
+};
+
+export type Operations = {
+  deletePet: Routes["/pets/{petId}"]["delete"];
+  getPet: Routes["/pets/{petId}"]["get"];
+};
@@ -0,0 +1,59 @@
+/*
+ * @Warning: THIS FILE IS AUTO-GENERATED - DO NOT EDIT
+ *
+ * Generator: openapi-tsgen@dev
+ * OpenAPI version: 3.0.3
+ * Generated at: 2026-02-10T00:00:00Z
+ */
+
+export const enum ColorEnum {
+  Red = "r",
+  Green = "g",
+  Blue = "b",
+}
+
+export const enum PriorityEnum {
+  /** @description Can wait */
+  Low = 1,
+  Medium = 2,
+  /** @description Drop everything */
+  High = 3,
+}
+
+export const enum TaskStateEnum {
+  OPEN = "open",
+  CLOSED = "closed",
+}
+
+export type Components = {
+  schemas: {
+    Color: ColorEnum;
+    Priority: PriorityEnum;
+    Task: {
+      color?: Components["schemas"]["Color"];
+      priority?: Components["schemas"]["Priority"];
+      state?: TaskStateEnum;
+    };
+  };
+};
+
+export type Routes = {
+  "/tasks": {
+    get: {
+      query: {
+        priority?: PriorityEnum;
+      };
+      responses: {
+        200: {
+          color?: ColorEnum;
+          priority?: PriorityEnum;
+          state?: TaskStateEnum;
+        }[];
+      };
+    };
+  };
+};
+
+export type Operations = {
+  getTasks: Routes["/tasks"]["get"];
+};
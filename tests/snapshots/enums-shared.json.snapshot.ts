@@ -44,6 +44,7 @@ export type Components = {
 
 export type Routes = {
   "/orders": {
+    /** @summary Create order */
     post: {
       requestBody: {
         id: string;
@@ -62,6 +63,7 @@ export type Routes = {
     };
   };
   "/users": {
+    /** @summary List users */
     get: {
       query: {
         status?: StatusEnum;
@@ -76,3 +78,8 @@ export type Routes = {
     };
   };
 };
+
+export type Operations = {
+  createOrder: Routes["/orders"]["post"];
+  listUsers: Routes["/users"]["get"];
+};
@@ -0,0 +1,107 @@
+/*
+ * @Warning: THIS FILE IS AUTO-GENERATED - DO NOT EDIT
+ *
+ * Generator: openapi-tsgen@dev
+ * OpenAPI version: 3.0.3
+ * Generated at: 2026-02-10T00:00:00Z
+ */
+
+export type Components = {
+  schemas: {
+    NewPet: {
+      name: string;
+      tag?: Components["schemas"]["tag"];
+    };
+    Owner: {
+      name: string;
+      pets?: Components["schemas"]["Pet"][];
+    };
+    Pet: (Components["schemas"]["NewPet"] & {
+      id: number;
+    });
+    error: {
+      code: number;
+      message: string;
+    };
+    tag: {
+      name?: string;
+    };
+  };
+  responses: {
+    /** @description Unexpected error */
+    Error: {
+      code: number;
+      message: string;
+    };
+  };
+  parameters: {
+    Limit: number;
+  };
+};
+
+export type Routes = {
+  "/pets": {
+    /** @summary List pets */
+    get: {
+      query: {
+        limit?: Components["parameters"]["Limit"];
+      };
+      responses: {
+        200: ({
+          name: string;
+          tag?: {
+            name?: string;
+          };
+        } & {
+          id: number;
+        })[];
+        default: Components["responses"]["Error"];
+      };
+    };
+    /** @summary Create pet */
+    post: {
+      requestBody: {
+        name: string;
+        tag?: {
+          name?: string;
+        };
+      };
+      responses: {
+        201: ({
+          name: string;
+          tag?: {
+            name?: string;
+          };
+        } & {
+          id: number;
+        });
+        default: Components["responses"]["Error"];
+      };
+    };
+  };
+  "/pets/{petId}": {
+    /** @summary Get pet */
+    get: {
+      params: {
+        petId: number;
+      };
+      responses: {
+        200: ({
+          name: string;
+          tag?: {
+            name?: string;
+          };
+        } & {
+          id: number;
+        });
+        default: Components["responses"]["Error"];
+      };
+    };
+  };
+};
+
+export type Operations = {
+  createPet: Routes["/pets"]["post"];
+  getPet: Routes["/pets/{petId}"]["get"];
+  listPets: Routes["/pets"]["get"];
+};
@@ -31,12 +31,13 @@ export type Components = {
 
 export type Routes = {
   "/maps": {
+    /** @summary Maps */
     post: {
       requestBody: {
         freeForm: Record<string, unknown>;
         mixedMap?: ({
-        id: string;
-      } & Record<string, (string | number)>);
+          id: string;
+        } & Record<string, (string | number)>);
         patternAndAdditional?: ({ [K in `s-${string}`]?: string } & Record<string, (boolean | string)>);
         patterned: { [K in (`${number}` | `x-${string}`)]?: (number | string) };
         stringMap?: Record<string, string>;
@@ -50,3 +51,7 @@ export type Routes = {
     };
   };
 };
+
+export type Operations = {
+  maps: Routes["/maps"]["post"];
+};
@@ -8,38 +8,45 @@
 
 export type Routes = {
   "/resource": {
+    /** @summary Delete */
     delete: {
       responses: {
         204: never;
       };
     };
+    /** @summary Get */
     get: {
       responses: {
         200: never;
       };
     };
+    /** @summary Head */
     head: {
       responses: {
         200: never;
       };
     };
+    /** @summary Options */
     options: {
       responses: {
         200: never;
       };
     };
+    /** @summary Patch */
     patch: {
       requestBody: Record<string, unknown>;
       responses: {
         200: never;
       };
     };
+    /** @summary Put */
     put: {
       requestBody: Record<string, unknown>;
       responses: {
         204: never;
       };
     };
+    /** @summary Trace */
     trace: {
       responses: {
         200: never;
@@ -47,3 +54,13 @@ export type Routes = {
     };
   };
 };
+
+export type Operations = {
+  deleteResource: Routes["/resource"]["delete"];
+  getResource: Routes["/resource"]["get"];
+  headResource: Routes["/resource"]["head"];
+  optionsResource: Routes["/resource"]["options"];
+  patchResource: Routes["/resource"]["patch"];
+  putResource: Routes["/resource"]["put"];
+  traceResource: Routes["/resource"]["trace"];
+};
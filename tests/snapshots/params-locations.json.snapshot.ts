@@ -8,6 +8,7 @@
 
 export type Routes = {
   "/items/{id}": {
+    /** @summary Get item */
     get: {
       params: {
         id: string;
@@ -27,11 +28,15 @@ export type Routes = {
             "X-Request-Id"?: string;
           };
           body: {
-          id?: string;
-          q?: string;
-        };
+            id?: string;
+            q?: string;
+          };
         };
       };
     };
   };
 };
+
+export type Operations = {
+  getItem: Routes["/items/{id}"]["get"];
+};
@@ -54,14 +54,23 @@ export type Components = {
       huntingSkill: CatHuntingSkillEnum;
       petType?: CatPetTypeCatEnum;
     });
+    Circle: {
+      radius: number;
+      shapeType: string;
+    };
     Dog: (Components["schemas"]["PetBase"] & {
+      /** @minimum 0 */
       packSize: number;
       petType?: DogPetTypeDogEnum;
     });
     MaybeString: (string | null);
     MixedAnyAllOne: ((string | number) & (MixedAnyAllOneAEnum | MixedAnyAllOneBEnum));
     OneOfWithNull: (string | null);
-    Pet: (Components["schemas"]["Cat"] | Components["schemas"]["Dog"]);
+    Pet: ((Omit<Components["schemas"]["Cat"], "petType"> & {
+      petType: "cat";
+    }) | (Omit<Components["schemas"]["Dog"], "petType"> & {
+      petType: "dog";
+    }));
     PetBase: {
       name: string;
       petType: string;
@@ -77,57 +86,89 @@ export type Components = {
     PolyResponse: {
       pet: Components["schemas"]["Pet"];
     };
+    Shape: ((Omit<Components["schemas"]["Circle"], "shapeType"> & {
+      shapeType: "Circle";
+    }) | (Omit<Components["schemas"]["Square"], "shapeType"> & {
+      shapeType: "Square";
+    }));
+    Square: {
+      shapeType: string;
+      side: number;
+    };
     StringOrNumber: (string | number);
-    Vehicle: (Components["schemas"]["Car"] | Components["schemas"]["Bike"]);
+    Vehicle: ((Omit<Components["schemas"]["Car"], "kind"> & {
+      kind: "car";
+    }) | (Omit<Components["schemas"]["Bike"], "kind"> & {
+      kind: "bike";
+    }));
   };
 };
 
 export type Routes = {
   "/polymorph": {
+    /** @summary Polymorph */
     post: {
       requestBody: {
         maybe?: (string | null);
         mixed?: ((string | number) & (MixedAnyAllOneAEnum | MixedAnyAllOneBEnum));
         oneOrNull?: (string | null);
-        pet: (({
-        name: string;
-        petType: string;
-      } & {
-        huntingSkill: CatHuntingSkillEnum;
-        petType?: CatPetTypeCatEnum;
-      }) | ({
-        name: string;
-        petType: string;
-      } & {
-        packSize: number;
-        petType?: DogPetTypeDogEnum;
-      }));
-        stringOrNumber?: (string | number);
-        vehicle: ({
-        doors: CarDoorsEnum;
-        kind: CarKindCarEnum;
-      } | {
-        hasBell: boolean;
-        kind: BikeKindBikeEnum;
-      });
-      };
-      responses: {
-        200: {
-          pet: (({
+        pet: ((Omit<({
           name: string;
           petType: string;
         } & {
           huntingSkill: CatHuntingSkillEnum;
           petType?: CatPetTypeCatEnum;
-        }) | ({
+        }), "petType"> & {
+          petType: "cat";
+        }) | (Omit<({
           name: string;
           petType: string;
         } & {
+          /** @minimum 0 */
           packSize: number;
           petType?: DogPetTypeDogEnum;
+        }), "petType"> & {
+          petType: "dog";
         }));
+        stringOrNumber?: (string | number);
+        vehicle: ((Omit<{
+          doors: CarDoorsEnum;
+          kind: CarKindCarEnum;
+        }, "kind"> & {
+          kind: "car";
+        }) | (Omit<{
+          hasBell: boolean;
+          kind: BikeKindBikeEnum;
+        }, "kind"> & {
+          kind: "bike";
+        }));
+      };
+      responses: {
+        200: {
+          pet: ((Omit<({
+            name: string;
+            petType: string;
+          } & {
+            huntingSkill: CatHuntingSkillEnum;
+            petType?: CatPetTypeCatEnum;
+          }), "petType"> & {
+            petType: "cat";
+          }) | (Omit<({
+            name: string;
+            petType: string;
+          } & {
+            /** @minimum 0 */
+            packSize: number;
+            petType?: DogPetTypeDogEnum;
+          }), "petType"> & {
+            petType: "dog";
+          }));
         };
       };
     };
   };
 };
+
+export type Operations = {
+  polymorph: Routes["/polymorph"]["post"];
+};
@@ -8,18 +8,24 @@
 
 export type Routes = {
   "/echo": {
+    /** @summary Echo */
     post: {
       requestBody: ({
         message?: string;
-      } | string | {
+      } | Blob | {
         name?: string;
       } | Record<string, unknown> | {
         description?: string;
-        file: string;
-      });
+        /** @format binary */
+        file: Blob;
+      } | string);
       responses: {
-        200: (Record<string, unknown> | string);
+        200: (Record<string, unknown> | Blob);
       };
     };
   };
 };
+
+export type Operations = {
+  echo: Routes["/echo"]["post"];
+};
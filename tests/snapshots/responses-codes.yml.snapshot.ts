@@ -8,6 +8,7 @@
 
 export type Routes = {
   "/status": {
+    /** @summary Status */
     get: {
       responses: {
         200: {
@@ -21,3 +22,7 @@ export type Routes = {
     };
   };
 };
+
+export type Operations = {
+  status: Routes["/status"]["get"];
+};
@@ -40,6 +40,7 @@ export type Components = {
 
 export type Routes = {
   "/secure": {
+    /** @summary Secure endpoint */
     get: {
       security: ({
         ApiKeyAuth: string[];
@@ -52,3 +53,7 @@ export type Routes = {
     };
   };
 };
+
+export type Operations = {
+  secure: Routes["/secure"]["get"];
+};
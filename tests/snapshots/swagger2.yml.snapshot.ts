@@ -0,0 +1,227 @@
+/*
+ * @Warning: THIS FILE IS AUTO-GENERATED - DO NOT EDIT
+ *
+ * Generator: openapi-tsgen@dev
+ * OpenAPI version: 3.0.3
+ * Generated at: 2026-02-10T00:00:00Z
+ */
+
+export const enum StatusEnum {
+  AVAILABLE = "available",
+  SOLD = "sold",
+}
+
+export type Servers = ({
+  url: "https://api.example.com/v1";
+} | {
+  url: "http://api.example.com/v1";
+})[];
+
+export type Components = {
+  schemas: {
+    Error: {
+      code: number;
+      message: string;
+    };
+    NewPet: {
+      name: string;
+      tag?: string;
+    };
+    Pet: {
+      /** @format int64 */
+      id: number;
+      kind: string;
+      name: string;
+      tag?: (string | null);
+    };
+  };
+  responses: {
+    /** @description Not found */
+    NotFound: {
+      code: number;
+      message: string;
+    };
+  };
+  requestBodies: {
+    PetBody: {
+      name: string;
+      tag?: string;
+    };
+  };
+  parameters: {
+    /**
+     * @minimum 1
+     * @maximum 100
+     */
+    Limit: number;
+  };
+  securitySchemes: {
+    apiKey: {
+      in: "header";
+      name: "X-API-Key";
+      type: "apiKey";
+    };
+    basicAuth: {
+      scheme: "basic";
+      type: "http";
+    };
+    petstoreAuth: {
+      flows: {
+        authorizationCode: {
+          authorizationUrl: string;
+          tokenUrl: string;
+          scopes: {
+            "read:pets": string;
+            "write:pets": string;
+          };
+        };
+      };
+      type: "oauth2";
+    };
+  };
+};
+
+export type Routes = {
+  "/pets": {
+    /** @summary List pets */
+    get: {
+      query: {
+        /**
+         * @minimum 1
+         * @maximum 100
+         */
+        limit?: Components["parameters"]["Limit"];
+        status?: StatusEnum;
+        tags?: string[];
+      };
+      security: {
+        apiKey: string[];
+      }[];
+      servers: ({
+        url: "https://api.example.com/v1";
+      } | {
+        url: "http://api.example.com/v1";
+      })[];
+      responses: {
+        200: {
+          headers: {
+            /** @description Total number of pets */
+            "X-Total-Count"?: number;
+          };
+          body: {
+            /** @format int64 */
+            id: number;
+            kind: string;
+            name: string;
+            tag?: (string | null);
+          }[];
+        };
+      };
+    };
+    post: {
+      security: {
+        apiKey: string[];
+      }[];
+      servers: ({
+        url: "https://api.example.com/v1";
+      } | {
+        url: "http://api.example.com/v1";
+      })[];
+      requestBody: Components["requestBodies"]["PetBody"];
+      responses: {
+        201: {
+          /** @format int64 */
+          id: number;
+          kind: string;
+          name: string;
+          tag?: (string | null);
+        };
+      };
+    };
+  };
+  "/pets/{petId}": {
+    get: {
+      params: {
+        petId: string;
+      };
+      security: {
+        apiKey: string[];
+      }[];
+      servers: ({
+        url: "https://api.example.com/v1";
+      } | {
+        url: "http://api.example.com/v1";
+      })[];
+      responses: {
+        200: {
+          /** @format int64 */
+          id: number;
+          kind: string;
+          name: string;
+          tag?: (string | null);
+        };
+        404: Components["responses"]["NotFound"];
+      };
+    };
+    put: {
+      params: {
+        petId: string;
+      };
+      security: {
+        apiKey: string[];
+      }[];
+      servers: ({
+        url: "https://api.example.com/v1";
+      } | {
+        url: "http://api.example.com/v1";
+      })[];
+      requestBody: {
+        name: string;
+        tag?: string;
+      };
+      responses: {
+        200: {
+          /** @format int64 */
+          id: number;
+          kind: string;
+          name: string;
+          tag?: (string | null);
+        };
+      };
+    };
+  };
+  "/pets/{petId}/photo": {
+    post: {
+      params: {
+        petId: string;
+      };
+      security: {
+        apiKey: string[];
+      }[];
+      servers: ({
+        url: "https://api.example.com/v1";
+      } | {
+        url: "http://api.example.com/v1";
+      })[];
+      requestBody: {
+        caption?: string;
+        /**
+         * @description Photo to upload
+         * @format binary
+         */
+        file: Blob;
+      };
+      responses: {
+        204: never;
+      };
+    };
+  };
+};
+
+export type Operations = {
+  createPet: Routes["/pets"]["post"];
+  getPet: Routes["/pets/{petId}"]["get"];
+  listPets: Routes["/pets"]["get"];
+  updatePet: Routes["/pets/{petId}"]["put"];
+  uploadPhoto: Routes["/pets/{petId}/photo"]["post"];
+};
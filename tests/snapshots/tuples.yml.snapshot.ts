@@ -0,0 +1,57 @@
+/*
+ * @Warning: THIS FILE IS AUTO-GENERATED - DO NOT EDIT
+ *
+ * Generator: openapi-tsgen@dev
+ * OpenAPI version: 3.1.0
+ * Generated at: 2026-02-10T00:00:00Z
+ */
+
+export type Components = {
+  schemas: {
+    /**
+     * @minItems 2
+     * @maxItems 2
+     */
+    Coordinate: [number, number];
+    Empty: [];
+    /** @minItems 1 */
+    Labeled: [string, ...number[]];
+    Legacy: [string?, number?];
+    Open: [boolean?, ...unknown[]];
+    /** @minItems 2 */
+    Position: [number, number, number?];
+    /**
+     * @minItems 3
+     * @maxItems 3
+     */
+    Rgb: number[];
+    Route: {
+      /** @minItems 2 */
+      bbox?: [Components["schemas"]["Coordinate"], Components["schemas"]["Coordinate"]];
+      path: Components["schemas"]["Coordinate"][];
+    };
+    /**
+     * @minItems 1
+     * @maxItems 10
+     */
+    Tags: string[];
+  };
+};
+
+export type Routes = {
+  "/routes": {
+    get: {
+      responses: {
+        200: {
+          /** @minItems 2 */
+          bbox?: [[number, number], [number, number]];
+          path: [number, number][];
+        };
+      };
+    };
+  };
+};
+
+export type Operations = {
+  getRoutes: Routes["/routes"]["get"];
+};
@@ -0,0 +1,57 @@
+/*
+ * @Warning: THIS FILE IS AUTO-GENERATED - DO NOT EDIT
+ *
+ * Generator: openapi-tsgen@dev
+ * OpenAPI version: 3.1.0
+ * Generated at: 2026-02-10T00:00:00Z
+ */
+
+export type Components = {
+  schemas: {
+    Item: {
+      count?: (number | null);
+      description?: (string | null);
+      meta?: Components["schemas"]["NullableObject"];
+      name: string;
+      tags: ((string | number)[] | null);
+    };
+    NullableList: (string[] | null);
+    NullableObject: ({
+      id: string;
+    } | null);
+    NullableString: (string | null);
+    NumberOrString: (number | string);
+  };
+};
+
+export type Routes = {
+  "/items": {
+    /** @summary Create item */
+    post: {
+      requestBody: {
+        count?: (number | null);
+        description?: (string | null);
+        meta?: ({
+          id: string;
+        } | null);
+        name: string;
+        tags: ((string | number)[] | null);
+      };
+      responses: {
+        200: {
+          count?: (number | null);
+          description?: (string | null);
+          meta?: ({
+            id: string;
+          } | null);
+          name: string;
+          tags: ((string | number)[] | null);
+        };
+      };
+    };
+  };
+};
+
+export type Operations = {
+  createItem: Routes["/items"]["post"];
+};
@@ -13,6 +13,7 @@ export type Servers = {
 
 export type Routes = {
   "/events": {
+    /** @summary Create event */
     post: {
       servers: {
         description: "Primary API";
@@ -28,8 +29,13 @@ export type Routes = {
   };
 };
 
+export type Operations = {
+  createEvent: Routes["/events"]["post"];
+};
+
 export type Webhooks = {
   "user.created": {
+    /** @summary User created webhook */
     post: {
       servers: {
         description: "Primary API";
@@ -0,0 +1,183 @@
+/*
+ * @Warning: THIS FILE IS AUTO-GENERATED - DO NOT EDIT
+ *
+ * Generator: openapi-tsgen@dev
+ * OpenAPI version: 3.0.3
+ * Generated at: 2026-02-10T00:00:00Z
+ */
+
+export const enum CardKindCardEnum {
+  CARD = "card",
+}
+
+export const enum InvoiceKindInvoiceEnum {
+  INVOICE = "invoice",
+}
+
+export const enum OrderPriorityEnum {
+  VALUE_1 = 1,
+  VALUE_2 = 2,
+  VALUE_3 = 3,
+}
+
+export const enum StatusEnum {
+  OPEN = "open",
+  SHIPPED = "shipped",
+  CANCELLED = "cancelled",
+}
+
+export type Components = {
+  schemas: {
+    Card: {
+      kind: CardKindCardEnum;
+      /**
+       * @minLength 4
+       * @maxLength 4
+       */
+      last4: string;
+    };
+    Category: {
+      children?: Components["schemas"]["Category"][];
+      name: string;
+    };
+    Invoice: (Components["schemas"]["Party"] & {
+      kind: InvoiceKindInvoiceEnum;
+    });
+    Line: {
+      /**
+       * @minimum 0
+       * @exclusiveMinimum true
+       */
+      price?: number;
+      /**
+       * @minimum 1
+       * @maximum 99
+       */
+      quantity: number;
+      /** @pattern ^[A-Z]{3}-\d+$ */
+      sku: string;
+    };
+    Order: {
+      /** @format uuid */
+      id: string;
+      /** @minItems 1 */
+      lines: Components["schemas"]["Line"][];
+      metadata?: Record<string, string>;
+      /** @maxLength 140 */
+      note?: (string | null);
+      payment?: (Components["schemas"]["Card"] | Components["schemas"]["Invoice"]);
+      priority?: OrderPriorityEnum;
+      status: Components["schemas"]["Status"];
+    };
+    Party: {
+      /** @format email */
+      email?: string;
+      name: string;
+    };
+    Problem: {
+      detail?: string;
+      title: string;
+    };
+    Status: StatusEnum;
+  };
+};
+
+export type Routes = {
+  "/orders/{orderId}": {
+    /** @summary Replace order */
+    put: {
+      params: {
+        /** @format uuid */
+        orderId: string;
+      };
+      query: {
+        dryRun?: boolean;
+      };
+      requestBody: {
+        /** @minItems 1 */
+        lines: {
+          /**
+           * @minimum 0
+           * @exclusiveMinimum true
+           */
+          price?: number;
+          /**
+           * @minimum 1
+           * @maximum 99
+           */
+          quantity: number;
+          /** @pattern ^[A-Z]{3}-\d+$ */
+          sku: string;
+        }[];
+        metadata?: Record<string, string>;
+        /** @maxLength 140 */
+        note?: (string | null);
+        payment?: ({
+          kind: CardKindCardEnum;
+          /**
+           * @minLength 4
+           * @maxLength 4
+           */
+          last4: string;
+        } | ({
+          /** @format email */
+          email?: string;
+          name: string;
+        } & {
+          kind: InvoiceKindInvoiceEnum;
+        }));
+        priority?: OrderPriorityEnum;
+        status: StatusEnum;
+      };
+      responses: {
+        200: {
+          /** @format uuid */
+          id: string;
+          /** @minItems 1 */
+          lines: {
+            /**
+             * @minimum 0
+             * @exclusiveMinimum true
+             */
+            price?: number;
+            /**
+             * @minimum 1
+             * @maximum 99
+             */
+            quantity: number;
+            /** @pattern ^[A-Z]{3}-\d+$ */
+            sku: string;
+          }[];
+          metadata?: Record<string, string>;
+          /** @maxLength 140 */
+          note?: (string | null);
+          payment?: ({
+            kind: CardKindCardEnum;
+            /**
+             * @minLength 4
+             * @maxLength 4
+             */
+            last4: string;
+          } | ({
+            /** @format email */
+            email?: string;
+            name: string;
+          } & {
+            kind: InvoiceKindInvoiceEnum;
+          }));
+          priority?: OrderPriorityEnum;
+          status: StatusEnum;
+        };
+        204: never;
+        default: {
+          detail?: string;
+          title: string;
+        };
+      };
+    };
+  };
+};
+
+export type Operations = {
+  replaceOrder: Routes["/orders/{orderId}"]["put"];
+};
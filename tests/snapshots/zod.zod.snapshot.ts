@@ -0,0 +1,96 @@
+/*
+ * @Warning: THIS FILE IS AUTO-GENERATED - DO NOT EDIT
+ *
+ * Generator: openapi-tsgen@dev
+ * OpenAPI version: 3.0.3
+ * Generated at: 2026-02-10T00:00:00Z
+ */
+
+import { z } from "zod";
+
+export const CardSchema = z.object({
+  kind: z.enum(["card"]),
+  last4: z.string().min(4).max(4),
+});
+
+export const CategorySchema: z.ZodTypeAny = z.object({
+  children: z.array(z.lazy(() => CategorySchema)).optional(),
+  name: z.string(),
+});
+
+export const PartySchema = z.object({
+  email: z.string().email().optional(),
+  name: z.string(),
+});
+
+export const InvoiceSchema = z.intersection(PartySchema, z.object({
+  kind: z.enum(["invoice"]),
+}));
+
+export const LineSchema = z.object({
+  price: z.number().gt(0).optional(),
+  quantity: z.number().int().gte(1).lte(99),
+  sku: z.string().regex(new RegExp("^[A-Z]{3}-\\d+$")),
+});
+
+export const StatusSchema = z.enum(["open", "shipped", "cancelled"]);
+
+export const OrderSchema = z.object({
+  id: z.string().uuid(),
+  lines: z.array(LineSchema).min(1),
+  metadata: z.record(z.string(), z.string()).optional(),
+  note: z.string().max(140).nullable().optional(),
+  payment: z.union([CardSchema, InvoiceSchema]).optional(),
+  priority: z.union([z.literal(1), z.literal(2), z.literal(3)]).optional(),
+  status: StatusSchema,
+}).strict();
+
+export const ProblemSchema = z.object({
+  detail: z.string().optional(),
+  title: z.string(),
+});
+
+export const schemas = {
+  Card: CardSchema,
+  Category: CategorySchema,
+  Invoice: InvoiceSchema,
+  Line: LineSchema,
+  Order: OrderSchema,
+  Party: PartySchema,
+  Problem: ProblemSchema,
+  Status: StatusSchema,
+};
+
+export const routes = {
+  "/orders/{orderId}": {
+    put: {
+      params: z.object({
+        orderId: z.string().uuid(),
+      }),
+      query: z.object({
+        dryRun: z.boolean().optional(),
+      }),
+      requestBody: z.object({
+        lines: z.array(LineSchema).min(1),
+        metadata: z.record(z.string(), z.string()).optional(),
+        note: z.string().max(140).nullable().optional(),
+        payment: z.union([CardSchema, InvoiceSchema]).optional(),
+        priority: z.union([z.literal(1), z.literal(2), z.literal(3)]).optional(),
+        status: StatusSchema,
+      }).strict(),
+      responses: {
+        200: z.object({
+          id: z.string().uuid(),
+          lines: z.array(LineSchema).min(1),
+          metadata: z.record(z.string(), z.string()).optional(),
+          note: z.string().max(140).nullable().optional(),
+          payment: z.union([CardSchema, InvoiceSchema]).optional(),
+          priority: z.union([z.literal(1), z.literal(2), z.literal(3)]).optional(),
+          status: StatusSchema,
+        }).strict(),
+        204: z.undefined(),
+        default: ProblemSchema,
+      },
+    },
+  },
+};
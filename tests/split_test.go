@@ -0,0 +1,292 @@
+package tests
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+const splitSpec = `openapi: 3.1.0
+info:
+  title: Split
+  version: "1.0.0"
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      parameters:
+        - name: status
+          in: query
+          schema:
+            $ref: "#/components/schemas/Status"
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Pet"
+    post:
+      operationId: createPet
+      tags: [pets, admin]
+      responses:
+        "201":
+          description: created
+  /orders:
+    get:
+      operationId: listOrders
+      tags: [Store Orders]
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: string
+  /health:
+    get:
+      responses:
+        "204":
+          description: ok
+webhooks:
+  petAdded:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Pet"
+      responses:
+        "200":
+          $ref: "#/components/responses/Ack"
+components:
+  responses:
+    Ack:
+      description: ok
+      content:
+        application/json:
+          schema:
+            type: boolean
+  schemas:
+    Status:
+      type: string
+      enum: [available, sold]
+    Pet:
+      type: object
+      properties:
+        status:
+          $ref: "#/components/schemas/Status"
+`
+
+func splitFiles(t *testing.T, opts schema.Options) map[string]string {
+	t.Helper()
+	res, err := fixedGenerator(opts).Generate(strings.NewReader(splitSpec))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if res.Types != "" {
+		t.Fatalf("split output also filled Types:\n%s", res.Types)
+	}
+	return res.Files
+}
+
+func assertFiles(t *testing.T, files map[string]string, want ...string) {
+	t.Helper()
+	got := make([]string, 0, len(files))
+	for name := range files {
+		got = append(got, name)
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("files = %v, want %v", got, want)
+	}
+}
+
+func assertContains(t *testing.T, file, content string, want ...string) {
+	t.Helper()
+	for _, w := range want {
+		if !strings.Contains(content, w) {
+			t.Fatalf("%s missing:\n%s\nin:\n%s", file, w, content)
+		}
+	}
+}
+
+func TestSplitBySection(t *testing.T) {
+	files := splitFiles(t, schema.Options{Split: schema.SplitSection, OperationAliases: true})
+	assertFiles(t, files, "enums.ts", "components.ts", "routes.ts", "webhooks.ts", "index.ts")
+
+	assertContains(t, "enums.ts", files["enums.ts"], "export const enum StatusEnum {")
+	assertContains(t, "components.ts", files["components.ts"],
+		"import type { StatusEnum } from \"./enums\";\n\nexport type Components = {")
+	assertContains(t, "routes.ts", files["routes.ts"],
+		"import type { StatusEnum } from \"./enums\";\n\nexport type Routes = {",
+		"export type Operations = {",
+		"export type ListPetsQuery = Routes[\"/pets\"][\"get\"][\"query\"];")
+	assertContains(t, "webhooks.ts", files["webhooks.ts"],
+		"import type { Components } from \"./components\";\nimport type { StatusEnum } from \"./enums\";\n\nexport type Webhooks = {")
+	assertContains(t, "index.ts", files["index.ts"],
+		"export * from \"./enums\";\n"+
+			"export * from \"./components\";\n"+
+			"export * from \"./routes\";\n"+
+			"export * from \"./webhooks\";\n")
+	for name, content := range files {
+		if !strings.HasPrefix(content, "/*\n * @Warning: THIS FILE IS AUTO-GENERATED") {
+			t.Fatalf("%s has no generated header:\n%s", name, content)
+		}
+	}
+}
+
+func TestSplitByTag(t *testing.T) {
+	files := splitFiles(t, schema.Options{Split: schema.SplitTag, OperationAliases: true})
+	assertFiles(t, files, "enums.ts", "components.ts", "default.routes.ts", "pets.routes.ts",
+		"store-orders.routes.ts", "routes.ts", "webhooks.ts", "index.ts")
+
+	assertContains(t, "pets.routes.ts", files["pets.routes.ts"],
+		"import type { StatusEnum } from \"./enums\";\n\nexport type PetsRoutes = {",
+		"export type PetsOperations = {\n  createPet: PetsRoutes[\"/pets\"][\"post\"];\n  listPets: PetsRoutes[\"/pets\"][\"get\"];\n};",
+		"export type ListPetsQuery = PetsRoutes[\"/pets\"][\"get\"][\"query\"];")
+	assertContains(t, "store-orders.routes.ts", files["store-orders.routes.ts"],
+		"export type StoreOrdersRoutes = {\n  \"/orders\": {")
+	if strings.Contains(files["store-orders.routes.ts"], "import type") {
+		t.Fatalf("unexpected import in store-orders.routes.ts:\n%s", files["store-orders.routes.ts"])
+	}
+	assertContains(t, "default.routes.ts", files["default.routes.ts"],
+		"export type DefaultRoutes = {\n  \"/health\": {",
+		"getHealth: DefaultRoutes[\"/health\"][\"get\"];")
+	assertContains(t, "routes.ts", files["routes.ts"],
+		"import type { DefaultOperations, DefaultRoutes } from \"./default.routes\";\n"+
+			"import type { PetsOperations, PetsRoutes } from \"./pets.routes\";\n"+
+			"import type { StoreOrdersOperations, StoreOrdersRoutes } from \"./store-orders.routes\";\n",
+		"export type Routes = DefaultRoutes & PetsRoutes & StoreOrdersRoutes;",
+		"export type Operations = DefaultOperations & PetsOperations & StoreOrdersOperations;")
+	assertContains(t, "index.ts", files["index.ts"],
+		"export * from \"./pets.routes\";\nexport * from \"./store-orders.routes\";\nexport * from \"./routes\";\n")
+}
+
+func TestWriteOutputsSplitDirectory(t *testing.T) {
+	pinGeneratorInfo(t)
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "api.yml")
+	out := filepath.Join(dir, "types")
+	writeFile(t, spec, splitSpec)
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		t.Fatalf("create output dir: %v", err)
+	}
+	writeFile(t, filepath.Join(out, "old.routes.ts"), "/*\n * @Warning: THIS FILE IS AUTO-GENERATED - DO NOT EDIT\n */\n\nexport type OldRoutes = {};\n")
+	writeFile(t, filepath.Join(out, "custom.ts"), "export type Custom = string;\n")
+
+	if _, err := schema.WriteOutputs(spec, schema.OutputPaths{Types: out}, schema.Options{Split: schema.SplitTag}); err != nil {
+		t.Fatalf("write outputs: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "old.routes.ts")); !os.IsNotExist(err) {
+		t.Fatalf("stale generated file was not removed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "custom.ts")); err != nil {
+		t.Fatalf("hand-written file was removed: %v", err)
+	}
+	first, err := os.ReadFile(filepath.Join(out, "pets.routes.ts"))
+	if err != nil {
+		t.Fatalf("read pets.routes.ts: %v", err)
+	}
+
+	schema.Now = func() time.Time { return time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC) }
+	if _, err := schema.WriteOutputs(spec, schema.OutputPaths{Types: out}, schema.Options{Split: schema.SplitTag}); err != nil {
+		t.Fatalf("rewrite outputs: %v", err)
+	}
+	second, err := os.ReadFile(filepath.Join(out, "pets.routes.ts"))
+	if err != nil {
+		t.Fatalf("read pets.routes.ts: %v", err)
+	}
+	if string(first) != string(second) {
+		t.Fatalf("unchanged module was rewritten:\n%s", diffText(string(first), string(second)))
+	}
+
+	if _, err := schema.WriteOutputs(spec, schema.OutputPaths{Types: out}, schema.Options{Split: schema.SplitSection}); err != nil {
+		t.Fatalf("write section outputs: %v", err)
+	}
+	for _, stale := range []string{"pets.routes.ts", "default.routes.ts", "store-orders.routes.ts"} {
+		if _, err := os.Stat(filepath.Join(out, stale)); !os.IsNotExist(err) {
+			t.Fatalf("%s left behind after switching split mode: %v", stale, err)
+		}
+	}
+}
+
+func TestSplitRejectsStdoutAndUnknownMode(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "api.yml")
+	writeFile(t, spec, splitSpec)
+
+	_, err := schema.WriteOutputs(spec, schema.OutputPaths{Types: schema.StdioPath}, schema.Options{Split: schema.SplitSection})
+	if !errors.Is(err, schema.ErrStdoutSplit) {
+		t.Fatalf("err = %v, want ErrStdoutSplit", err)
+	}
+	_, err = schema.NewGenerator(schema.Options{Split: "files"}).Generate(strings.NewReader(splitSpec))
+	if !errors.Is(err, schema.ErrUnknownSplitMode) {
+		t.Fatalf("err = %v, want ErrUnknownSplitMode", err)
+	}
+}
+
+func TestWriteOutputsSplitKeepsClientInDirectory(t *testing.T) {
+	pinGeneratorInfo(t)
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "api.yml")
+	out := filepath.Join(dir, "api")
+	client := filepath.Join(out, "client.ts")
+	writeFile(t, spec, splitSpec)
+
+	paths := schema.OutputPaths{Types: out, Client: client}
+	if _, err := schema.WriteOutputs(spec, paths, schema.Options{Split: schema.SplitSection}); err != nil {
+		t.Fatalf("write outputs: %v", err)
+	}
+	first, err := os.ReadFile(client)
+	if err != nil {
+		t.Fatalf("read client: %v", err)
+	}
+	if !strings.Contains(string(first), "from \"./index\";") {
+		t.Fatalf("client does not import the index module:\n%s", first)
+	}
+
+	schema.Now = func() time.Time { return time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC) }
+	if _, err := schema.WriteOutputs(spec, paths, schema.Options{Split: schema.SplitSection}); err != nil {
+		t.Fatalf("rewrite outputs: %v", err)
+	}
+	second, err := os.ReadFile(client)
+	if err != nil {
+		t.Fatalf("client removed by the stale sweep: %v", err)
+	}
+	if string(first) != string(second) {
+		t.Fatalf("unchanged client was rewritten:\n%s", diffText(string(first), string(second)))
+	}
+}
+
+func TestWriteOutputsSplitKeepsSiblingGeneratedFiles(t *testing.T) {
+	pinGeneratorInfo(t)
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "api.yml")
+	out := filepath.Join(dir, "out")
+	zod := filepath.Join(out, "zod.ts")
+	writeFile(t, spec, splitSpec)
+
+	single := schema.OutputPaths{Types: filepath.Join(out, "types.ts"), Zod: zod}
+	if _, err := schema.WriteOutputs(spec, single, schema.Options{}); err != nil {
+		t.Fatalf("write single-file outputs: %v", err)
+	}
+	if _, err := schema.WriteOutputs(spec, schema.OutputPaths{Types: out}, schema.Options{Split: schema.SplitSection}); err != nil {
+		t.Fatalf("write split outputs: %v", err)
+	}
+	for _, sibling := range []string{zod, single.Types} {
+		if _, err := os.Stat(sibling); err != nil {
+			t.Fatalf("sibling generated file removed by the stale sweep: %v", err)
+		}
+	}
+}
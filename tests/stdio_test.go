@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+func TestLoadDocumentDetectsFormatFromContent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "spec")
+	writeFile(t, path, `{
+  "openapi": "3.1.0",
+  "info": {"title": "Sniff", "version": "1.0.0"},
+  "components": {
+    "schemas": {
+      "Pet": {"$ref": "./pet"}
+    }
+  }
+}
+`)
+	writeFile(t, filepath.Join(dir, "pet"), `type: object
+properties:
+  name:
+    type: string
+`)
+
+	doc, err := schema.LoadDocument(path, schema.InputAuto)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if doc.Info.Title != "Sniff" || doc.Components == nil {
+		t.Fatalf("unexpected document: %#v", doc)
+	}
+	if _, ok := doc.Components.Schemas["pet"]; !ok {
+		t.Fatalf("expected YAML ref target to be hoisted, got %#v", doc.Components.Schemas)
+	}
+}
+
+const stdioSpec = `openapi: 3.1.0
+info:
+  title: Pipe
+  version: "1.0.0"
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+func TestWriteOutputsFromStdinToStdout(t *testing.T) {
+	var buf bytes.Buffer
+	paths := schema.OutputPaths{Types: schema.StdioPath, Stdout: &buf}
+	if _, err := schema.WriteOutputsFrom([]byte(stdioSpec), paths, schema.Options{}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Pet: {") {
+		t.Fatalf("expected Pet in output, got:\n%s", buf.String())
+	}
+
+	err := schema.WriteSchema(schema.StdioPath, schema.StdioPath, schema.InputAuto)
+	if !errors.Is(err, schema.ErrStdinPath) {
+		t.Fatalf("expected ErrStdinPath, got %v", err)
+	}
+
+	err = schema.WriteClient(filepath.Join(t.TempDir(), "api.yml"), filepath.Join(t.TempDir(), "client.ts"), schema.StdioPath, schema.InputAuto)
+	if !errors.Is(err, schema.ErrStdoutTypesImport) {
+		t.Fatalf("expected ErrStdoutTypesImport, got %v", err)
+	}
+}
+
+func TestValidateData(t *testing.T) {
+	errs, err := schema.ValidateData([]byte(stdioSpec+`paths:
+  /pets/{id}:
+    get:
+      responses:
+        "200":
+          description: ok
+`), schema.InputAuto)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Message, "id") {
+		t.Fatalf("expected one path parameter problem, got %v", errs)
+	}
+}
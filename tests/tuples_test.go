@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+func TestZodTuples(t *testing.T) {
+	res, err := schema.NewGenerator(schema.Options{Zod: true}).GenerateFS(os.DirFS("fixtures"), "tuples.fixture.yml")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	for _, want := range []string{
+		"export const CoordinateSchema = z.tuple([z.number(), z.number()]);",
+		"export const EmptySchema = z.tuple([]);",
+		"export const LabeledSchema = z.tuple([z.string()]).rest(z.number());",
+		"export const RgbSchema = z.array(z.number().int()).min(3).max(3);",
+		"bbox: z.tuple([CoordinateSchema, CoordinateSchema]).optional(),",
+		"export const PositionSchema = z.union([z.tuple([z.number(), z.number()]), z.tuple([z.number(), z.number(), z.number()])]);",
+		"export const OpenSchema = z.union([z.tuple([]), z.tuple([z.boolean()]).rest(z.unknown())]);",
+	} {
+		if !strings.Contains(res.Zod, want) {
+			t.Fatalf("missing %q in:\n%s", want, res.Zod)
+		}
+	}
+}
+
+func TestFixedTuplesOptIn(t *testing.T) {
+	res, err := schema.NewGenerator(schema.Options{Zod: true, FixedTuples: true}).GenerateFS(os.DirFS("fixtures"), "tuples.fixture.yml")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	for _, want := range []string{
+		"Rgb: [number, number, number];",
+		"Tags: string[];",
+	} {
+		if !strings.Contains(res.Types, want) {
+			t.Fatalf("missing %q in:\n%s", want, res.Types)
+		}
+	}
+	for _, want := range []string{
+		"export const RgbSchema = z.tuple([z.number().int(), z.number().int(), z.number().int()]);",
+		"export const TagsSchema = z.array(z.string()).min(1).max(10);",
+	} {
+		if !strings.Contains(res.Zod, want) {
+			t.Fatalf("missing %q in:\n%s", want, res.Zod)
+		}
+	}
+}
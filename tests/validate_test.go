@@ -0,0 +1,200 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/brownhounds/openapi-tsgen/schema"
+)
+
+func TestValidateReportsStructuralErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "openapi.yml"), `openapi: 3.1.0
+info:
+  title: Broken
+paths:
+  /pets/{petId}/toys/{toyId}:
+    parameters:
+      - $ref: "./params.yml#/PetId"
+    get:
+      operationId: getToy
+      security:
+        - apiKey: []
+      responses:
+        "2XX":
+          description: ok
+        "600":
+          description: bad
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Missing"
+  /pets:
+    post:
+      operationId: getToy
+      parameters:
+        - name: extra
+          in: path
+          required: true
+      responses:
+        default:
+          description: ok
+components:
+  securitySchemes:
+    bearer:
+      type: http
+      scheme: bearer
+`)
+	writeFile(t, filepath.Join(dir, "params.yml"), `PetId:
+  name: petId
+  in: path
+`)
+
+	errs, err := schema.Validate(filepath.Join(dir, "openapi.yml"), schema.InputAuto)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	want := []string{
+		`openapi.yml:3:3: info is missing required field "version"`,
+		`openapi.yml:9:7: GET /pets/{petId}/toys/{toyId}: path template parameter "toyId" is not declared with in: path`,
+		`openapi.yml:11:11: security requirement references unknown security scheme "apiKey"`,
+		`openapi.yml:15:9: GET /pets/{petId}/toys/{toyId}: invalid response status code "600"`,
+		`openapi.yml:20:23: unresolved $ref "#/components/schemas/Missing"`,
+		`openapi.yml:23:20: duplicate operationId "getToy" (first used at openapi.yml:9:20)`,
+		`openapi.yml:25:11: POST /pets: path parameter "extra" is not in the path template`,
+		`params.yml:2:3: GET /pets/{petId}/toys/{toyId}: path parameter "petId" must be required`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %d: %v", len(want), len(errs), errs)
+	}
+	for i, e := range errs {
+		e.File = filepath.Base(e.File)
+		if e.RelatedFile != "" {
+			e.RelatedFile = filepath.Base(e.RelatedFile)
+		}
+		if e.String() != want[i] {
+			t.Errorf("error %d:\n got: %s\nwant: %s", i, e, want[i])
+		}
+	}
+}
+
+func TestValidateAcceptsValidSpec(t *testing.T) {
+	errs, err := schema.Validate(filepath.Join("fixtures", "client.fixture.yml"), schema.InputAuto)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+}
+
+func TestValidateReportsDanglingExternalRefs(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "openapi.yml"), `openapi: 3.1.0
+info:
+  title: Refs
+  version: "1.0.0"
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - $ref: "./params.yml#/Bad"
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: "./missing.yml#/Pet"
+  /toys:
+    get:
+      operationId: listPets
+      responses:
+        "600":
+          description: bad
+`)
+	writeFile(t, filepath.Join(dir, "params.yml"), `Limit:
+  name: limit
+  in: query
+`)
+
+	errs, err := schema.Validate(filepath.Join(dir, "openapi.yml"), schema.InputAuto)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	want := []string{
+		`openapi.yml:10:17: unresolved $ref "./params.yml#/Bad"`,
+		`openapi.yml:17:23: unresolved $ref "./missing.yml#/Pet"`,
+		`openapi.yml:20:20: duplicate operationId "listPets" (first used at ` + filepath.Join(dir, "openapi.yml") + `:8:20)`,
+		`openapi.yml:22:9: GET /toys: invalid response status code "600"`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %d: %v", len(want), len(errs), errs)
+	}
+	for i, e := range errs {
+		e.File = filepath.Base(e.File)
+		if e.String() != want[i] {
+			t.Errorf("error %d:\n got: %s\nwant: %s", i, e, want[i])
+		}
+	}
+}
+
+func TestValidateIgnoresRefsInLiteralValues(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "openapi.yml"), `openapi: 3.1.0
+info:
+  title: Literals
+  version: "1.0.0"
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+              example:
+                $ref: "not a ref"
+              examples:
+                sample:
+                  value:
+                    $ref: "#/nowhere"
+components:
+  schemas:
+    Pet:
+      type: object
+      example:
+        $ref: "not a ref"
+      default:
+        $ref: "#/nowhere"
+      properties:
+        $ref:
+          type: string
+        kind:
+          const:
+            $ref: "#/nowhere"
+        tags:
+          enum:
+            - $ref: "#/nowhere"
+        owner:
+          $ref: "#/components/schemas/Missing"
+`)
+
+	errs, err := schema.Validate(filepath.Join(dir, "openapi.yml"), schema.InputAuto)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	want := `openapi.yml:40:17: unresolved $ref "#/components/schemas/Missing"`
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+	errs[0].File = filepath.Base(errs[0].File)
+	if errs[0].String() != want {
+		t.Fatalf("got: %s\nwant: %s", errs[0], want)
+	}
+}